@@ -0,0 +1,260 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	db "github.com/online-tryout/parsing-sheets-api/db/sqlc"
+	"github.com/online-tryout/parsing-sheets-api/parser"
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+var unsafeFileNameChars = regexp.MustCompile(`[^\w\- ]+`)
+
+type ExportTryoutRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+type ExportGoogleSheetRequest struct {
+	// Email is given write access to the new spreadsheet, which is otherwise
+	// only reachable by the service account that creates it
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ExportGoogleSheetResponse struct {
+	SpreadsheetId string `json:"spreadsheetId"`
+	Url           string `json:"url"`
+}
+
+// Export Tryout
+// @Summary Export a tryout to an .xlsx workbook
+// @Description Writes a tryout with its modules, questions and options in the layout accepted by the import, so it can be edited and imported again
+// @Tags Parser Sheets
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param id path string true "Tryout ID"
+// @Success 200 {file} file "Success"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 404 {object} ErrorResponse "Not Found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security BearerAuth
+// @Router /api/parsing-sheets/tryouts/{id}/export [get]
+func (server *Server) exportTryout(ctx *gin.Context) {
+	var req ExportTryoutRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	tree, ok := server.loadTryoutTree(ctx, uuid.MustParse(req.ID))
+	if !ok {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := util.WriteXLSX(&buf, parser.Format(tree)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	fileName := unsafeFileNameChars.ReplaceAllString(tree.Metadata.Title, "")
+	if len(fileName) == 0 {
+		fileName = req.ID
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fileName+".xlsx"))
+	ctx.Data(http.StatusOK, xlsxContentType, buf.Bytes())
+}
+
+// Export Tryout to Google Sheets
+// @Summary Export a tryout to a new google sheet
+// @Description Creates a google sheet holding a tryout in the layout accepted by the import and shares it with the given email
+// @Tags Parser Sheets
+// @Accept json
+// @Produce json
+// @Param id path string true "Tryout ID"
+// @Param requestBody body ExportGoogleSheetRequest true "Account to share the new google sheet with"
+// @Success 201 {object} ExportGoogleSheetResponse "Created"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 404 {object} ErrorResponse "Not Found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security BearerAuth
+// @Router /api/parsing-sheets/tryouts/{id}/export/google [post]
+func (server *Server) exportGoogleSheet(ctx *gin.Context) {
+	var uri ExportTryoutRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req ExportGoogleSheetRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	tree, ok := server.loadTryoutTree(ctx, uuid.MustParse(uri.ID))
+	if !ok {
+		return
+	}
+
+	spreadsheet, err := util.CreateSpreadsheet(credentials, tree.Metadata.Title, parser.Format(tree), req.Email)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, ExportGoogleSheetResponse{
+		SpreadsheetId: spreadsheet.SpreadsheetId,
+		Url:           spreadsheet.SpreadsheetUrl,
+	})
+}
+
+// loadTryoutTree writes the error response itself and reports whether the
+// tryout was loaded.
+func (server *Server) loadTryoutTree(ctx *gin.Context, id uuid.UUID) (*parser.Tryout, bool) {
+	tree, err := loadTryout(ctx, server.store, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, false
+	}
+	return tree, true
+}
+
+// loadTryout reads a stored tryout back into the tree the parser produces.
+func loadTryout(ctx context.Context, q db.Querier, id uuid.UUID) (*parser.Tryout, error) {
+	tryout, err := q.GetTryout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &parser.Tryout{
+		Metadata: parser.Metadata{
+			Title:       tryout.Title,
+			Price:       tryout.Price,
+			Status:      tryout.Status,
+			StartedAt:   tryout.StartedAt.Format(time.RFC3339),
+			EndedAt:     tryout.EndedAt.Format(time.RFC3339),
+			Description: tryout.Description,
+			Duration:    tryout.Duration.Int32,
+		},
+		Modules: []parser.Module{},
+	}
+
+	modules, err := q.ListModulesByTryout(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, module := range modules {
+		parsedModule := parser.Module{
+			Title:        module.Title,
+			ModuleOrder:  module.ModuleOrder.Int32,
+			Duration:     module.Duration.Int32,
+			Instructions: module.Instructions,
+			Description:  module.Description,
+			Passages:     []parser.Passage{},
+			Questions:    []parser.Question{},
+		}
+
+		passages, err := q.ListPassagesByModule(ctx, module.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		passageOrders := map[uuid.UUID]int32{}
+		for _, passage := range passages {
+			passageOrders[passage.ID] = passage.PassageOrder.Int32
+			parsedModule.Passages = append(parsedModule.Passages, parser.Passage{
+				Content:      passage.Content,
+				PassageOrder: passage.PassageOrder.Int32,
+			})
+		}
+
+		questions, err := q.ListQuestionsByModule(ctx, module.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, question := range questions {
+			parsedQuestion, err := loadQuestion(ctx, q, question)
+			if err != nil {
+				return nil, err
+			}
+			if question.PassageId.Valid {
+				order := passageOrders[question.PassageId.UUID]
+				parsedQuestion.PassageOrder = &order
+			}
+			parsedModule.Questions = append(parsedModule.Questions, *parsedQuestion)
+		}
+
+		tree.Modules = append(tree.Modules, parsedModule)
+	}
+
+	return tree, nil
+}
+
+func loadQuestion(ctx context.Context, q db.Querier, question db.Questions) (*parser.Question, error) {
+	parsedQuestion := &parser.Question{
+		Content:          question.Content,
+		QuestionOrder:    question.QuestionOrder.Int32,
+		Type:             question.Type,
+		Options:          []parser.Option{},
+		AcceptedAnswers:  []string{},
+		Tags:             []parser.Tag{},
+		CaseSensitive:    question.CaseSensitive,
+		NumericAnswer:    float64Pointer(question.NumericAnswer),
+		NumericTolerance: float64Pointer(question.NumericTolerance),
+		Explanation:      question.Explanation,
+		Points:           question.Points,
+		WrongPenalty:     question.WrongPenalty,
+		BlankScore:       question.BlankScore,
+	}
+
+	options, err := q.ListOptionsByQuestion(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, option := range options {
+		parsedQuestion.Options = append(parsedQuestion.Options, parser.Option{
+			Content:     option.Content,
+			IsTrue:      option.IsTrue,
+			OptionOrder: option.OptionOrder.Int32,
+			Explanation: option.Explanation,
+			Points:      float64Pointer(option.Points),
+		})
+	}
+
+	answers, err := q.ListAcceptedAnswersByQuestion(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, answer := range answers {
+		parsedQuestion.AcceptedAnswers = append(parsedQuestion.AcceptedAnswers, answer.Content)
+	}
+
+	tags, err := q.ListTagsByQuestion(ctx, question.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tag := range tags {
+		parsedQuestion.Tags = append(parsedQuestion.Tags, parser.Tag{Type: tag.Type, Name: tag.Name})
+	}
+
+	return parsedQuestion, nil
+}
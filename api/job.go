@@ -0,0 +1,344 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/online-tryout/parsing-sheets-api/broker"
+	db "github.com/online-tryout/parsing-sheets-api/db/sqlc"
+	"github.com/online-tryout/parsing-sheets-api/parser"
+)
+
+const (
+	jobStatusQueued    = "queued"
+	jobStatusRunning   = "running"
+	jobStatusRetrying  = "retrying"
+	jobStatusSucceeded = "succeeded"
+	jobStatusFailed    = "failed"
+)
+
+const (
+	moduleStatusPending  = "pending"
+	moduleStatusImported = "imported"
+)
+
+type ModuleProgress struct {
+	Title     string `json:"title"`
+	Status    string `json:"status"`
+	Questions int    `json:"questions"`
+}
+
+type ImportJobResponse struct {
+	ID              uuid.UUID                   `json:"id"`
+	Url             string                      `json:"url"`
+	FileName        string                      `json:"fileName"`
+	Status          string                      `json:"status"`
+	TotalModules    int                         `json:"totalModules"`
+	ImportedModules int                         `json:"importedModules"`
+	Progress        []ModuleProgress            `json:"progress"`
+	TryoutId        *uuid.UUID                  `json:"tryoutId"`
+	Errors          []parser.Issue              `json:"errors"`
+	Result          *ParsingSheetsParamResponse `json:"result"`
+	StartedAt       *time.Time                  `json:"startedAt"`
+	FinishedAt      *time.Time                  `json:"finishedAt"`
+	UpdatedAt       time.Time                   `json:"updatedAt"`
+	CreatedAt       time.Time                   `json:"createdAt"`
+}
+
+type GetImportJobRequest struct {
+	ID string `uri:"id" binding:"required,uuid"`
+}
+
+// Get Import Job
+// @Summary Get the status of an import job
+// @Description Reports the status, per-module progress, resulting tryout and parse errors of an import job
+// @Tags Parser Sheets
+// @Produce json
+// @Param id path string true "Import job ID"
+// @Success 200 {object} ImportJobResponse "Success"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 404 {object} ErrorResponse "Not Found"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security BearerAuth
+// @Router /api/parsing-sheets/jobs/{id} [get]
+func (server *Server) getImportJob(ctx *gin.Context) {
+	var req GetImportJobRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	job, err := server.store.GetImportJob(ctx, uuid.MustParse(req.ID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp, err := newImportJobResponse(job)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// runImportJob is registered as the broker job handler. Failures caused by
+// the spreadsheet itself are recorded on the job and returned as a failed
+// result; any other error is returned so the message is handled by the broker,
+// and only fails the job when final is set since the message is retried
+// otherwise.
+func (server *Server) runImportJob(msg broker.Message, final bool) (*broker.Result, error) {
+	ctx := context.Background()
+
+	jobID, err := uuid.Parse(msg.JobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// a job that already succeeded is not imported again
+	job, err := server.store.GetImportJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Status == jobStatusSucceeded {
+		log.Printf("import job %s already succeeded, replaying its result", jobID)
+		return succeededJobResult(msg.ProcessID, job)
+	}
+
+	sheets, err := msg.LoadSheets(ctx, server.store)
+	if err != nil {
+		return nil, server.retryImportJob(ctx, jobID, err, final)
+	}
+
+	sheets, err = parser.SelectSheets(sheets, parser.Selection{
+		IgnorePrefix: server.config.SheetIgnorePrefix,
+		Sheets:       msg.Sheets,
+	})
+	if err != nil {
+		server.failImportJob(ctx, jobID, err)
+		return broker.NewFailedResult(msg.ProcessID, err), nil
+	}
+
+	tree, err := parser.Parse(sheets)
+	if err != nil {
+		server.failImportJob(ctx, jobID, err)
+		return broker.NewFailedResult(msg.ProcessID, err), nil
+	}
+
+	msg = msg.WithMetadata(tree.Metadata)
+	startedAtTime, endedAtTime, err := msg.Validate()
+	if err != nil {
+		server.failImportJob(ctx, jobID, err)
+		return broker.NewFailedResult(msg.ProcessID, err), nil
+	}
+
+	progress := make([]ModuleProgress, len(tree.Modules))
+	for i, module := range tree.Modules {
+		progress[i] = ModuleProgress{
+			Title:     module.Title,
+			Status:    moduleStatusPending,
+			Questions: len(module.Questions),
+		}
+	}
+
+	progressJSON, err := json.Marshal(progress)
+	if err != nil {
+		return nil, err
+	}
+
+	err = server.store.StartImportJob(ctx, db.StartImportJobParams{
+		ID:           jobID,
+		TotalModules: int32(len(progress)),
+		Progress:     progressJSON,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	arg := db.CreateTryoutParams{
+		Title:       msg.Title,
+		Price:       msg.Price,
+		Status:      msg.Status,
+		StartedAt:   startedAtTime,
+		EndedAt:     endedAtTime,
+		Description: msg.Description,
+		Duration:    sql.NullInt32{Int32: msg.Duration, Valid: msg.Duration > 0},
+	}
+
+	// progress is written outside the transaction so it is visible while the
+	// import is still running; RetryImportJob and FailImportJob mark the
+	// modules pending again when the transaction is rolled back
+	afterModule := func(i int) error {
+		progress[i].Status = moduleStatusImported
+		progressJSON, err := json.Marshal(progress)
+		if err != nil {
+			return err
+		}
+
+		return server.store.UpdateImportJobProgress(ctx, db.UpdateImportJobProgressParams{
+			ID:              jobID,
+			ImportedModules: int32(i + 1),
+			Progress:        progressJSON,
+		})
+	}
+
+	// the job is completed and the message recorded in the same transaction
+	// as the tryout, so a redelivered message can't import it twice
+	var result *broker.Result
+	err = server.store.ExecTx(ctx, func(q db.Querier) error {
+		resp, err := createTryout(ctx, q, arg, tree, afterModule)
+		if err != nil {
+			return err
+		}
+
+		respJSON, err := json.Marshal(resp)
+		if err != nil {
+			return err
+		}
+
+		err = q.CompleteImportJob(ctx, db.CompleteImportJobParams{
+			ID:       jobID,
+			TryoutId: uuid.NullUUID{UUID: resp.ID, Valid: true},
+			Result:   respJSON,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := q.DeleteImportJobFile(ctx, jobID); err != nil {
+			return err
+		}
+
+		result = broker.NewSucceededResult(msg.ProcessID, &resp.ID, tree)
+		return broker.RecordResult(ctx, q, result)
+	})
+	if err != nil {
+		return nil, server.retryImportJob(ctx, jobID, err, final)
+	}
+
+	return result, nil
+}
+
+// succeededJobResult rebuilds the broker result of a job from the tryout
+// stored when it succeeded.
+func succeededJobResult(processID string, job db.ImportJobs) (*broker.Result, error) {
+	var resp ParsingSheetsParamResponse
+	if err := json.Unmarshal(job.Result, &resp); err != nil {
+		return nil, err
+	}
+
+	result := &broker.Result{
+		ProcessID: processID,
+		Status:    broker.ResultSucceeded,
+		TryoutID:  &resp.ID,
+		Modules:   len(resp.Modules),
+		Errors:    []parser.Issue{},
+	}
+	for _, module := range resp.Modules {
+		result.Questions += len(module.Questions)
+		for _, question := range module.Questions {
+			result.Options += len(question.Options)
+		}
+	}
+	return result, nil
+}
+
+// retryImportJob returns cause to the broker, failing the job only when the
+// message won't be retried. Until then the job is marked retrying with the
+// progress of the rolled back attempt cleared.
+func (server *Server) retryImportJob(ctx context.Context, jobID uuid.UUID, cause error, final bool) error {
+	if final {
+		server.failImportJob(ctx, jobID, cause)
+		return cause
+	}
+
+	issuesJSON, err := json.Marshal(parser.ErrorIssues(cause))
+	if err != nil {
+		log.Printf("can't encode errors of import job %s: %v", jobID, err)
+		return cause
+	}
+
+	err = server.store.RetryImportJob(ctx, db.RetryImportJobParams{
+		ID:     jobID,
+		Errors: issuesJSON,
+	})
+	if err != nil {
+		log.Printf("can't mark import job %s as retrying: %v", jobID, err)
+	}
+	return cause
+}
+
+func (server *Server) failImportJob(ctx context.Context, jobID uuid.UUID, cause error) {
+	issuesJSON, err := json.Marshal(parser.ErrorIssues(cause))
+	if err != nil {
+		log.Printf("can't encode errors of import job %s: %v", jobID, err)
+		return
+	}
+
+	err = server.store.FailImportJob(ctx, db.FailImportJobParams{
+		ID:     jobID,
+		Errors: issuesJSON,
+	})
+	if err != nil {
+		log.Printf("can't mark import job %s as failed: %v", jobID, err)
+	}
+
+	// a failed job is never run again, so its uploaded file isn't needed
+	if err := server.store.DeleteImportJobFile(ctx, jobID); err != nil {
+		log.Printf("can't delete the file of import job %s: %v", jobID, err)
+	}
+}
+
+func newImportJobResponse(job db.ImportJobs) (*ImportJobResponse, error) {
+	resp := ImportJobResponse{
+		ID:              job.ID,
+		Url:             job.Url,
+		FileName:        job.FileName,
+		Status:          job.Status,
+		TotalModules:    int(job.TotalModules),
+		ImportedModules: int(job.ImportedModules),
+		Progress:        []ModuleProgress{},
+		Errors:          []parser.Issue{},
+		UpdatedAt:       job.UpdatedAt,
+		CreatedAt:       job.CreatedAt,
+	}
+
+	if err := json.Unmarshal(job.Progress, &resp.Progress); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(job.Errors, &resp.Errors); err != nil {
+		return nil, err
+	}
+
+	if job.Status == jobStatusSucceeded {
+		resp.Result = &ParsingSheetsParamResponse{}
+		if err := json.Unmarshal(job.Result, resp.Result); err != nil {
+			return nil, err
+		}
+	}
+
+	if job.TryoutId.Valid {
+		resp.TryoutId = &job.TryoutId.UUID
+	}
+	if job.StartedAt.Valid {
+		resp.StartedAt = &job.StartedAt.Time
+	}
+	if job.FinishedAt.Valid {
+		resp.FinishedAt = &job.FinishedAt.Time
+	}
+
+	return &resp, nil
+}
@@ -1,9 +1,11 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lib/pq"
 	"github.com/online-tryout/parsing-sheets-api/broker"
 	db "github.com/online-tryout/parsing-sheets-api/db/sqlc"
 	"github.com/online-tryout/parsing-sheets-api/docs"
@@ -16,17 +18,19 @@ type Server struct {
 	config     util.Config
 	store      db.Store
 	router     *gin.Engine
-	rabbitmq   broker.RabbitMq
+	rabbitmq   *broker.RabbitMq
 }
 
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
-func NewServer(config *util.Config, rmq *broker.RabbitMq) (*Server, error) {
-	server := &Server{config: *config, rabbitmq: *rmq}
+func NewServer(config *util.Config, store db.Store, rmq *broker.RabbitMq) (*Server, error) {
+	server := &Server{config: *config, store: store, rabbitmq: rmq}
 	server.setupRouter()
 
+	rmq.JobHandler = server.runImportJob
+
 	return server, nil
 }
 
@@ -44,13 +48,28 @@ func (server *Server) setupRouter() {
 
 	// health check api
 	router.GET("/api/parsing-sheets/health", func(ctx *gin.Context) {
-		ctx.JSON(http.StatusOK, gin.H{"message": "server is running"})
+		if !server.rabbitmq.IsConnected() {
+			ctx.JSON(http.StatusServiceUnavailable, gin.H{"message": "server is running", "rabbitmq": "disconnected"})
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{"message": "server is running", "rabbitmq": "connected"})
 	})
 
 	router.POST("/api/parsing-sheets/parse", server.parsingSheets)
+	router.POST("/api/parsing-sheets/validate", server.validateSheets)
+	router.POST("/api/parsing-sheets/upload", server.uploadSheets)
+	router.GET("/api/parsing-sheets/template", server.getTemplate)
+	router.GET("/api/parsing-sheets/jobs/:id", server.getImportJob)
+	router.GET("/api/parsing-sheets/tryouts/:id/export", server.exportTryout)
+	router.POST("/api/parsing-sheets/tryouts/:id/export/google", server.exportGoogleSheet)
 	server.router = router
 }
 
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
 }
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
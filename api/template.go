@@ -0,0 +1,71 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/online-tryout/parsing-sheets-api/parser"
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+const (
+	templateFormatXLSX   = "xlsx"
+	templateFormatGoogle = "google"
+	templateTitle        = "Tryout Template"
+)
+
+type TemplateRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=xlsx google"`
+	// Email is given write access to the google sheet and is required with
+	// format google, since nobody else could open it
+	Email string `form:"email" binding:"required_if=Format google,omitempty,email"`
+}
+
+// Template
+// @Summary Get a spreadsheet template to fill in
+// @Description Produces a workbook with a README documenting the rules, a module sheet with the header and dropdowns on the answer, key, type and case sensitivity columns, and protected header rows. With format google a new google sheet is created instead
+// @Tags Parser Sheets
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce json
+// @Param format query string false "xlsx (default) or google" Enums(xlsx, google)
+// @Param email query string false "Account to share the google sheet with, required with format google"
+// @Success 200 {file} file "Success"
+// @Success 201 {object} ExportGoogleSheetResponse "Created"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security BearerAuth
+// @Router /api/parsing-sheets/template [get]
+func (server *Server) getTemplate(ctx *gin.Context) {
+	var req TemplateRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sheets := parser.Template(server.config.SheetIgnorePrefix)
+
+	if req.Format == templateFormatGoogle {
+		spreadsheet, err := util.CreateSpreadsheet(credentials, templateTitle, sheets, req.Email)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		ctx.JSON(http.StatusCreated, ExportGoogleSheetResponse{
+			SpreadsheetId: spreadsheet.SpreadsheetId,
+			Url:           spreadsheet.SpreadsheetUrl,
+		})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := util.WriteXLSX(&buf, sheets); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "tryout-template."+templateFormatXLSX))
+	ctx.Data(http.StatusOK, xlsxContentType, buf.Bytes())
+}
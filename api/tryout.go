@@ -1,29 +1,41 @@
 package api
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/online-tryout/parsing-sheets-api/broker"
 	db "github.com/online-tryout/parsing-sheets-api/db/sqlc"
+	"github.com/online-tryout/parsing-sheets-api/parser"
 	"github.com/online-tryout/parsing-sheets-api/util"
 )
 
 const (
-	credentials = "sheets-key.json"
+	credentials          = "sheets-key.json"
+	idempotencyKeyHeader = "Idempotency-Key"
 )
 
+// ParsingSheetsParamRequest fields that are left empty are read from the
+// README sheet of the spreadsheet.
 type ParsingSheetsParamRequest struct {
-	Title     string `json:"title"`
-	Price     string `json:"price"`
-	Status    string `json:"status"`
-	StartedAt string `json:"startedAt"`
-	EndedAt   string `json:"endedAt"`
-	Url       string `json:"url"`
+	Title       string `json:"title"`
+	Price       string `json:"price"`
+	Status      string `json:"status"`
+	StartedAt   string `json:"startedAt"`
+	EndedAt     string `json:"endedAt"`
+	Description string `json:"description"`
+	Duration    int32  `json:"duration" binding:"min=0"`
+	Url         string `json:"url"`
+	// Sheets lists the titles or gids of the sheets to import, all by default
+	Sheets []string `json:"sheets"`
 }
 
 type OptionResponse struct {
@@ -32,51 +44,96 @@ type OptionResponse struct {
 	Content     string    `json:"content"`
 	IsTrue      bool      `json:"isTrue"`
 	OptionOrder int       `json:"optionOrder"`
+	Explanation string    `json:"explanation"`
+	Points      *float64  `json:"points"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 	CreatedAt   time.Time `json:"createdAt"`
 }
 
+type AcceptedAnswerResponse struct {
+	ID          uuid.UUID `json:"id"`
+	QuestionId  uuid.UUID `json:"questionId"`
+	Content     string    `json:"content"`
+	AnswerOrder int       `json:"answerOrder"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+type TagResponse struct {
+	ID   uuid.UUID `json:"id"`
+	Type string    `json:"type"`
+	Name string    `json:"name"`
+}
+
+type PassageResponse struct {
+	ID           uuid.UUID `json:"id"`
+	ModuleId     uuid.UUID `json:"moduleId"`
+	Content      string    `json:"content"`
+	PassageOrder int       `json:"passageOrder"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
 type QuestionResponse struct {
-	ID            uuid.UUID        `json:"id"`
-	Content       string           `json:"content"`
-	ModuleId      uuid.UUID        `json:"moduleId"`
-	QuestionOrder int              `json:"questionOrder"`
-	UpdatedAt     time.Time        `json:"updatedAt"`
-	CreatedAt     time.Time        `json:"createdAt"`
-	Options       []OptionResponse `json:"options"`
+	ID               uuid.UUID                `json:"id"`
+	Content          string                   `json:"content"`
+	ModuleId         uuid.UUID                `json:"moduleId"`
+	QuestionOrder    int                      `json:"questionOrder"`
+	Type             string                   `json:"type"`
+	CaseSensitive    bool                     `json:"caseSensitive"`
+	NumericAnswer    *float64                 `json:"numericAnswer"`
+	NumericTolerance *float64                 `json:"numericTolerance"`
+	Explanation      string                   `json:"explanation"`
+	Points           float64                  `json:"points"`
+	WrongPenalty     float64                  `json:"wrongPenalty"`
+	BlankScore       float64                  `json:"blankScore"`
+	PassageId        *uuid.UUID               `json:"passageId"`
+	UpdatedAt        time.Time                `json:"updatedAt"`
+	CreatedAt        time.Time                `json:"createdAt"`
+	Options          []OptionResponse         `json:"options"`
+	AcceptedAnswers  []AcceptedAnswerResponse `json:"acceptedAnswers"`
+	Tags             []TagResponse            `json:"tags"`
 }
 
 type ModuleResponse struct {
-	ID          uuid.UUID          `json:"id"`
-	Title       string             `json:"title"`
-	TryoutId    uuid.UUID          `json:"tryoutId"`
-	ModuleOrder int                `json:"moduleOrder"`
-	UpdatedAt   time.Time          `json:"updatedAt"`
-	CreatedAt   time.Time          `json:"createdAt"`
-	Questions   []QuestionResponse `json:"questions"`
+	ID           uuid.UUID          `json:"id"`
+	Title        string             `json:"title"`
+	TryoutId     uuid.UUID          `json:"tryoutId"`
+	ModuleOrder  int                `json:"moduleOrder"`
+	Duration     *int32             `json:"duration"`
+	Instructions string             `json:"instructions"`
+	Description  string             `json:"description"`
+	UpdatedAt    time.Time          `json:"updatedAt"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	Passages     []PassageResponse  `json:"passages"`
+	Questions    []QuestionResponse `json:"questions"`
 }
 
 type ParsingSheetsParamResponse struct {
-	ID        uuid.UUID        `json:"id"`
-	Title     string           `json:"title"`
-	Price     string           `json:"price"`
-	Status    string           `json:"status"`
-	StartedAt time.Time        `json:"startedAt"`
-	EndedAt   time.Time        `json:"endedAt"`
-	UpdatedAt time.Time        `json:"updatedAt"`
-	CreatedAt time.Time        `json:"createdAt"`
-	Modules   []ModuleResponse `json:"modules"`
+	ID          uuid.UUID        `json:"id"`
+	Title       string           `json:"title"`
+	Price       string           `json:"price"`
+	Status      string           `json:"status"`
+	StartedAt   time.Time        `json:"startedAt"`
+	EndedAt     time.Time        `json:"endedAt"`
+	Description string           `json:"description"`
+	Duration    *int32           `json:"duration"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	Modules     []ModuleResponse `json:"modules"`
 }
 
 // Parsing Sheets
 // @Summary Create a new tryout by parsing google sheets
-// @Description Creates a new tryout by parsing google sheet with the provided parameters
+// @Description Queues an import job that creates a new tryout by parsing google sheet with the provided parameters. Parameters left empty are read from the README sheet
 // @Tags Parser Sheets
 // @Accept json
 // @Produce json
 // @Param requestBody body ParsingSheetsParamRequest true "Request body to create a new tryout by parsing google sheets"
-// @Success 200 {object} ParsingSheetsParamResponse "Success"
+// @Param Idempotency-Key header string false "Repeating a request with the same key returns the job created by the first one"
+// @Success 202 {object} ImportJobResponse "Accepted"
 // @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 409 {object} ErrorResponse "Conflict"
 // @Failure 500 {object} ErrorResponse "Internal Server Error"
 // @Security BearerAuth
 // @Router /api/parsing-sheets/parse [post]
@@ -87,192 +144,280 @@ func (server *Server) parsingSheets(ctx *gin.Context) {
 		return
 	}
 
-	startedAtTime, err := time.Parse(time.RFC3339, req.StartedAt)
-	if err != nil {
+	if err := validateSchedule(req.StartedAt, req.EndedAt); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	endedAtTime, err := time.Parse(time.RFC3339, req.EndedAt)
-	if err != nil {
+	if _, err := util.GetSheetID(req.Url); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	arg := db.CreateTryoutParams{
-		Title:     req.Title,
-		Price:     req.Price,
-		Status:    req.Status,
-		StartedAt: startedAtTime,
-		EndedAt:   endedAtTime,
+	server.enqueueImportJob(ctx, db.CreateImportJobParams{Url: req.Url}, nil, broker.Message{
+		Title:       req.Title,
+		Price:       req.Price,
+		Status:      req.Status,
+		StartedAt:   req.StartedAt,
+		EndedAt:     req.EndedAt,
+		Description: req.Description,
+		Duration:    req.Duration,
+		URL:         req.Url,
+		Sheets:      req.Sheets,
+	})
+}
+
+// validateSchedule checks the dates given in a request. Empty dates are read
+// from the README sheet by the import job.
+func validateSchedule(startedAt, endedAt string) error {
+	for _, value := range []string{startedAt, endedAt} {
+		if len(value) == 0 {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enqueueImportJob creates an import job for the spreadsheet and publishes msg
+// to run it, or replays the job of an earlier request with the same
+// Idempotency-Key header. An uploaded workbook is stored with the job rather
+// than in the message.
+func (server *Server) enqueueImportJob(ctx *gin.Context, arg db.CreateImportJobParams, workbook []byte, msg broker.Message) {
+	arg.IdempotencyKey = sql.NullString{String: ctx.GetHeader(idempotencyKeyHeader)}
+	arg.IdempotencyKey.Valid = len(arg.IdempotencyKey.String) > 0
+
+	if arg.IdempotencyKey.Valid {
+		job, err := server.store.GetImportJobByIdempotencyKey(ctx, arg.IdempotencyKey)
+		if err == nil {
+			replayImportJob(ctx, job, arg)
+			return
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 	}
 
-	tryout, err := server.store.CreateTryout(ctx, arg)
+	var job db.ImportJobs
+	err := server.store.ExecTx(ctx, func(q db.Querier) error {
+		var err error
+		job, err = q.CreateImportJob(ctx, arg)
+		if err != nil || len(workbook) == 0 {
+			return err
+		}
+		return q.CreateImportJobFile(ctx, db.CreateImportJobFileParams{JobId: job.ID, Content: workbook})
+	})
 	if err != nil {
+		// a concurrent request with the same key created the job first
+		if arg.IdempotencyKey.Valid && isUniqueViolation(err) {
+			job, err = server.store.GetImportJobByIdempotencyKey(ctx, arg.IdempotencyKey)
+			if err == nil {
+				replayImportJob(ctx, job, arg)
+				return
+			}
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	resp := ParsingSheetsParamResponse{
-		ID:        tryout.ID,
-		Title:     tryout.Title,
-		Price:     tryout.Price,
-		Status:    tryout.Status,
-		StartedAt: tryout.StartedAt,
-		EndedAt:   tryout.EndedAt,
-		UpdatedAt: tryout.UpdatedAt,
-		CreatedAt: tryout.CreatedAt,
-		Modules:   []ModuleResponse{},
+	msg.ProcessID = job.ID.String()
+	msg.JobID = job.ID.String()
+	body, err := json.Marshal(msg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
 	}
 
-	client, err := util.GetSheetsClient(credentials)
+	err = server.rabbitmq.PublishEvent(broker.ParsingSheetsQueue, body)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("unable to get Google Sheets client: %v", err)))
+		// the job will never run, so a retry of this request has to be free to
+		// create another one instead of replaying the failed job
+		server.failImportJob(ctx, job.ID, err)
+		if arg.IdempotencyKey.Valid {
+			if err := server.store.ReleaseImportJobIdempotencyKey(ctx, job.ID); err != nil {
+				log.Printf("can't release idempotency key of import job %s: %v", job.ID, err)
+			}
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	spreadsheetID, err := util.GetSheetID(req.Url)
+	resp, err := newImportJobResponse(job)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	spreadsheetInfo, err := util.GetSpreadsheetInfo(client, spreadsheetID)
+
+	ctx.JSON(http.StatusAccepted, resp)
+}
+
+func replayImportJob(ctx *gin.Context, job db.ImportJobs, arg db.CreateImportJobParams) {
+	if job.Url != arg.Url || job.FileName != arg.FileName {
+		ctx.JSON(http.StatusConflict, errorResponse(fmt.Errorf("idempotency key was already used for a different spreadsheet")))
+		return
+	}
+
+	resp, err := newImportJobResponse(job)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	for moduleOrder, sheet := range spreadsheetInfo.Sheets {
-		title := sheet.Properties.Title
-		row := sheet.Properties.GridProperties.RowCount
-		col := sheet.Properties.GridProperties.ColumnCount
+	ctx.JSON(http.StatusAccepted, resp)
+}
 
-		if title == "README" {
-			continue
-		}
+// createTryout inserts the parsed tryout tree using q. When afterModule is
+// set it is called with the index of every module once it has been inserted.
+func createTryout(ctx context.Context, q db.Querier, arg db.CreateTryoutParams, tree *parser.Tryout, afterModule func(int) error) (*ParsingSheetsParamResponse, error) {
+	tryout, err := q.CreateTryout(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
 
+	resp := ParsingSheetsParamResponse{
+		ID:          tryout.ID,
+		Title:       tryout.Title,
+		Price:       tryout.Price,
+		Status:      tryout.Status,
+		StartedAt:   tryout.StartedAt,
+		EndedAt:     tryout.EndedAt,
+		Description: tryout.Description,
+		Duration:    int32Pointer(tryout.Duration),
+		UpdatedAt:   tryout.UpdatedAt,
+		CreatedAt:   tryout.CreatedAt,
+		Modules:     []ModuleResponse{},
+	}
+
+	for i, parsedModule := range tree.Modules {
 		arg := db.CreateModuleParams{
-			Title:       title,
-			TryoutId:    tryout.ID,
-			ModuleOrder: sql.NullInt32{Int32: int32(moduleOrder), Valid: true},
+			Title:        parsedModule.Title,
+			TryoutId:     tryout.ID,
+			ModuleOrder:  sql.NullInt32{Int32: parsedModule.ModuleOrder, Valid: true},
+			Duration:     sql.NullInt32{Int32: parsedModule.Duration, Valid: parsedModule.Duration > 0},
+			Instructions: parsedModule.Instructions,
+			Description:  parsedModule.Description,
 		}
 
-		module, err := server.store.CreateModule(ctx, arg)
+		module, err := q.CreateModule(ctx, arg)
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
+			return nil, err
 		}
 		moduleResp := ModuleResponse{
-			ID:          module.ID,
-			Title:       module.Title,
-			TryoutId:    module.TryoutId,
-			ModuleOrder: int(module.ModuleOrder.Int32),
-			UpdatedAt:   module.UpdatedAt,
-			CreatedAt:   module.CreatedAt,
-			Questions:   []QuestionResponse{},
+			ID:           module.ID,
+			Title:        module.Title,
+			TryoutId:     module.TryoutId,
+			ModuleOrder:  int(module.ModuleOrder.Int32),
+			Duration:     int32Pointer(module.Duration),
+			Instructions: module.Instructions,
+			Description:  module.Description,
+			UpdatedAt:    module.UpdatedAt,
+			CreatedAt:    module.CreatedAt,
+			Passages:     []PassageResponse{},
+			Questions:    []QuestionResponse{},
 		}
 
-		data, err := util.FetchData(client, spreadsheetID, sheet.Properties.Title, fmt.Sprintf("A2:%s%d", util.NumberToColumnLetter(col), row))
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("unable to fetch data from sheet %s: %v", sheet.Properties.Title, err)))
-			return
-		}
-
-		var questionResp *QuestionResponse
-		var sheetsReader util.SheetsRowReader
-		var number string
-		var question string
-		var answer string
-		var option string
-
-		for i, row := range data {
-			for j, cell := range row {
-				switch j {
-				case 0:
-					number = fmt.Sprint(cell)
-				case 1:
-					question = fmt.Sprint(cell)
-				case 2:
-					answer = fmt.Sprint(cell)
-				case 3:
-					option = fmt.Sprint(cell)
-				}
+		passageIds := map[int32]uuid.UUID{}
+		for _, parsedPassage := range parsedModule.Passages {
+			arg := db.CreatePassageParams{
+				Content:      parsedPassage.Content,
+				ModuleId:     module.ID,
+				PassageOrder: sql.NullInt32{Int32: parsedPassage.PassageOrder, Valid: true},
 			}
-
-			if len(number) == 0 && len(question) == 0 && len(answer) == 0 && len(option) > 0 {
-				sheetsReader.Option = append(sheetsReader.Option, option)
-			} else if len(number) > 0 && len(question) > 0 && len(answer) > 0 && len(option) > 0 {
-				if !sheetsReader.IsEmpty() {
-					question, err := createQuestionAndOption(ctx, server, &module, &sheetsReader)
-					if err != nil {
-						ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-						return
-					}
-					questionResp = question
-					moduleResp.Questions = append(moduleResp.Questions, *questionResp)
-				}
-				sheetsReader = util.SheetsRowReader{
-					Number:   number,
-					Question: question,
-					Answer:   answer,
-					Option:   []string{option},
-				}
-			} else {
-				ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("data format was wrong: number %s, question %s, answer %s, option %s", number, question, answer, option)))
-				return
+			passage, err := q.CreatePassage(ctx, arg)
+			if err != nil {
+				return nil, err
 			}
+			passageIds[parsedPassage.PassageOrder] = passage.ID
+
+			moduleResp.Passages = append(moduleResp.Passages, PassageResponse{
+				ID:           passage.ID,
+				ModuleId:     passage.ModuleId,
+				Content:      passage.Content,
+				PassageOrder: int(passage.PassageOrder.Int32),
+				UpdatedAt:    passage.UpdatedAt,
+				CreatedAt:    passage.CreatedAt,
+			})
+		}
 
-			if i == len(data)-1 {
-				question, err := createQuestionAndOption(ctx, server, &module, &sheetsReader)
-				if err != nil {
-					ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-					return
-				}
-				questionResp = question
-				moduleResp.Questions = append(moduleResp.Questions, *questionResp)
+		for _, parsedQuestion := range parsedModule.Questions {
+			question, err := createQuestionAndOption(ctx, q, &module, passageIds, &parsedQuestion)
+			if err != nil {
+				return nil, err
 			}
+			moduleResp.Questions = append(moduleResp.Questions, *question)
 		}
 
 		resp.Modules = append(resp.Modules, moduleResp)
+
+		if afterModule != nil {
+			if err := afterModule(i); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	ctx.JSON(http.StatusOK, resp)
+	return &resp, nil
 }
 
-func createQuestionAndOption(ctx *gin.Context, server *Server, module *db.Modules, sheetsReader *util.SheetsRowReader) (*QuestionResponse, error) {
-	order, err := strconv.Atoi(sheetsReader.Number)
-	if err != nil {
-		return nil, err
-	}
+// createQuestionAndOption links the question to its passage through
+// passageIds, which maps the passage order to the passage inserted for it.
+func createQuestionAndOption(ctx context.Context, q db.Querier, module *db.Modules, passageIds map[int32]uuid.UUID, parsedQuestion *parser.Question) (*QuestionResponse, error) {
 	arg := db.CreateQuestionParams{
-		Content:       sheetsReader.Question,
-		ModuleId:      module.ID,
-		QuestionOrder: sql.NullInt32{Int32: int32(order), Valid: true},
+		Content:          parsedQuestion.Content,
+		ModuleId:         module.ID,
+		QuestionOrder:    sql.NullInt32{Int32: parsedQuestion.QuestionOrder, Valid: true},
+		Type:             parsedQuestion.Type,
+		CaseSensitive:    parsedQuestion.CaseSensitive,
+		NumericAnswer:    nullFloat64(parsedQuestion.NumericAnswer),
+		NumericTolerance: nullFloat64(parsedQuestion.NumericTolerance),
+		Explanation:      parsedQuestion.Explanation,
+		Points:           parsedQuestion.Points,
+		WrongPenalty:     parsedQuestion.WrongPenalty,
+		BlankScore:       parsedQuestion.BlankScore,
+	}
+	if parsedQuestion.PassageOrder != nil {
+		arg.PassageId = uuid.NullUUID{UUID: passageIds[*parsedQuestion.PassageOrder], Valid: true}
 	}
-	question, err := server.store.CreateQuestion(ctx, arg)
+	question, err := q.CreateQuestion(ctx, arg)
 	if err != nil {
 		return nil, err
 	}
 
 	questionResponse := QuestionResponse{
-		ID:            question.ID,
-		Content:       question.Content,
-		ModuleId:      question.ModuleId,
-		QuestionOrder: int(question.QuestionOrder.Int32),
-		UpdatedAt:     question.UpdatedAt,
-		CreatedAt:     question.CreatedAt,
+		ID:               question.ID,
+		Content:          question.Content,
+		ModuleId:         question.ModuleId,
+		QuestionOrder:    int(question.QuestionOrder.Int32),
+		Type:             question.Type,
+		CaseSensitive:    question.CaseSensitive,
+		NumericAnswer:    float64Pointer(question.NumericAnswer),
+		NumericTolerance: float64Pointer(question.NumericTolerance),
+		Explanation:      question.Explanation,
+		Points:           question.Points,
+		WrongPenalty:     question.WrongPenalty,
+		BlankScore:       question.BlankScore,
+		PassageId:        uuidPointer(question.PassageId),
+		UpdatedAt:        question.UpdatedAt,
+		CreatedAt:        question.CreatedAt,
+		AcceptedAnswers:  []AcceptedAnswerResponse{},
+		Tags:             []TagResponse{},
 	}
 
 	var options []OptionResponse
 
-	for optionOrder, option := range sheetsReader.Option {
+	for _, option := range parsedQuestion.Options {
 		arg := db.CreateOptionParams{
-			Content:     option,
+			Content:     option.Content,
 			QuestionId:  question.ID,
-			IsTrue:      option == sheetsReader.Option[int(sheetsReader.Answer[0])-int('A')],
-			OptionOrder: sql.NullInt32{Int32: int32(optionOrder) + 1, Valid: true},
+			IsTrue:      option.IsTrue,
+			OptionOrder: sql.NullInt32{Int32: option.OptionOrder, Valid: true},
+			Explanation: option.Explanation,
+			Points:      nullFloat64(option.Points),
 		}
-		dbOption, err := server.store.CreateOption(ctx, arg)
+		dbOption, err := q.CreateOption(ctx, arg)
 		if err != nil {
 			return nil, err
 		}
@@ -283,6 +428,8 @@ func createQuestionAndOption(ctx *gin.Context, server *Server, module *db.Module
 			Content:     dbOption.Content,
 			IsTrue:      dbOption.IsTrue,
 			OptionOrder: int(dbOption.OptionOrder.Int32),
+			Explanation: dbOption.Explanation,
+			Points:      float64Pointer(dbOption.Points),
 			UpdatedAt:   dbOption.UpdatedAt,
 			CreatedAt:   dbOption.CreatedAt,
 		})
@@ -290,5 +437,77 @@ func createQuestionAndOption(ctx *gin.Context, server *Server, module *db.Module
 
 	questionResponse.Options = options
 
+	for answerOrder, answer := range parsedQuestion.AcceptedAnswers {
+		arg := db.CreateAcceptedAnswerParams{
+			Content:     answer,
+			QuestionId:  question.ID,
+			AnswerOrder: sql.NullInt32{Int32: int32(answerOrder) + 1, Valid: true},
+		}
+		dbAnswer, err := q.CreateAcceptedAnswer(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+
+		questionResponse.AcceptedAnswers = append(questionResponse.AcceptedAnswers, AcceptedAnswerResponse{
+			ID:          dbAnswer.ID,
+			QuestionId:  dbAnswer.QuestionId,
+			Content:     dbAnswer.Content,
+			AnswerOrder: int(dbAnswer.AnswerOrder.Int32),
+			UpdatedAt:   dbAnswer.UpdatedAt,
+			CreatedAt:   dbAnswer.CreatedAt,
+		})
+	}
+
+	// tags are shared by every question with the same type and name
+	for tagOrder, parsedTag := range parsedQuestion.Tags {
+		tag, err := q.UpsertTag(ctx, db.UpsertTagParams{Type: parsedTag.Type, Name: parsedTag.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		arg := db.CreateQuestionTagParams{
+			QuestionId: question.ID,
+			TagId:      tag.ID,
+			TagOrder:   sql.NullInt32{Int32: int32(tagOrder) + 1, Valid: true},
+		}
+		if _, err := q.CreateQuestionTag(ctx, arg); err != nil {
+			return nil, err
+		}
+
+		questionResponse.Tags = append(questionResponse.Tags, TagResponse{
+			ID:   tag.ID,
+			Type: tag.Type,
+			Name: tag.Name,
+		})
+	}
+
 	return &questionResponse, nil
 }
+
+func nullFloat64(value *float64) sql.NullFloat64 {
+	if value == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *value, Valid: true}
+}
+
+func float64Pointer(value sql.NullFloat64) *float64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Float64
+}
+
+func uuidPointer(value uuid.NullUUID) *uuid.UUID {
+	if !value.Valid {
+		return nil
+	}
+	return &value.UUID
+}
+
+func int32Pointer(value sql.NullInt32) *int32 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int32
+}
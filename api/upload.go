@@ -0,0 +1,126 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/online-tryout/parsing-sheets-api/broker"
+	db "github.com/online-tryout/parsing-sheets-api/db/sqlc"
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+const (
+	maxUploadSize = 10 << 20
+	// maxUploadRequestSize leaves room for the other form fields and the
+	// multipart boundaries around the file
+	maxUploadRequestSize = maxUploadSize + 1<<20
+)
+
+// UploadSheetsParamRequest fields that are left empty are read from the
+// README sheet of the workbook.
+type UploadSheetsParamRequest struct {
+	File        *multipart.FileHeader `form:"file" binding:"required"`
+	Title       string                `form:"title"`
+	Price       string                `form:"price"`
+	Status      string                `form:"status"`
+	StartedAt   string                `form:"startedAt"`
+	EndedAt     string                `form:"endedAt"`
+	Description string                `form:"description"`
+	Duration    int32                 `form:"duration" binding:"min=0"`
+	// Sheets lists the titles or gids of the sheets to import, all by default
+	Sheets []string `form:"sheets"`
+}
+
+// Upload Sheets
+// @Summary Create a new tryout from an uploaded workbook
+// @Description Queues an import job that creates a new tryout from an .xlsx or .ods workbook, a zip of CSV files named after their modules or a single CSV file, with the same rules as a google sheet. Parameters left empty are read from the README sheet
+// @Tags Parser Sheets
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "The .xlsx, .ods, .zip or .csv file"
+// @Param title formData string false "Tryout title"
+// @Param price formData string false "Tryout price"
+// @Param status formData string false "Tryout status"
+// @Param startedAt formData string false "Start of the tryout in RFC 3339"
+// @Param endedAt formData string false "End of the tryout in RFC 3339"
+// @Param description formData string false "Tryout description"
+// @Param duration formData int false "Tryout duration in minutes"
+// @Param sheets formData []string false "Titles or gids of the sheets to import" collectionFormat(multi)
+// @Param Idempotency-Key header string false "Repeating a request with the same key returns the job created by the first one"
+// @Success 202 {object} ImportJobResponse "Accepted"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 409 {object} ErrorResponse "Conflict"
+// @Failure 413 {object} ErrorResponse "Request Entity Too Large"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security BearerAuth
+// @Router /api/parsing-sheets/upload [post]
+func (server *Server) uploadSheets(ctx *gin.Context) {
+	// the body is cut off before the multipart form is spooled to disk
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadRequestSize)
+
+	var req UploadSheetsParamRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(fmt.Errorf("file is larger than %d MB", maxUploadSize>>20)))
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if !util.IsWorkbook(req.File.Filename) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("file %s is not one of %s",
+			req.File.Filename, strings.Join(util.WorkbookExtensions, ", "))))
+		return
+	}
+
+	if req.File.Size > maxUploadSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(fmt.Errorf("file is larger than %d MB", maxUploadSize>>20)))
+		return
+	}
+
+	if err := validateSchedule(req.StartedAt, req.EndedAt); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	workbook, err := readUpload(req.File)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// a file that can't be opened is rejected before a job is created
+	if _, err := util.ReadWorkbook(req.File.Filename, workbook); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	server.enqueueImportJob(ctx, db.CreateImportJobParams{FileName: req.File.Filename}, workbook, broker.Message{
+		Title:       req.Title,
+		Price:       req.Price,
+		Status:      req.Status,
+		StartedAt:   req.StartedAt,
+		EndedAt:     req.EndedAt,
+		Description: req.Description,
+		Duration:    req.Duration,
+		Sheets:      req.Sheets,
+		FileName:    req.File.Filename,
+	})
+}
+
+func readUpload(header *multipart.FileHeader) ([]byte, error) {
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
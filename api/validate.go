@@ -0,0 +1,79 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/online-tryout/parsing-sheets-api/parser"
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+type ValidateSheetsParamRequest struct {
+	Url string `json:"url" binding:"required"`
+	// Sheets lists the titles or gids of the sheets to import, all by default
+	Sheets []string `json:"sheets"`
+}
+
+type ValidateSheetsParamResponse struct {
+	Valid    bool           `json:"valid"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+	Issues   []parser.Issue `json:"issues"`
+}
+
+// Validate Sheets
+// @Summary Validate a google sheet without importing it
+// @Description Walks every sheet and reports all errors and warnings with their A1 cell reference, without writing anything
+// @Tags Parser Sheets
+// @Accept json
+// @Produce json
+// @Param requestBody body ValidateSheetsParamRequest true "Request body to validate a google sheet"
+// @Success 200 {object} ValidateSheetsParamResponse "Success"
+// @Failure 400 {object} ErrorResponse "Bad Request"
+// @Failure 500 {object} ErrorResponse "Internal Server Error"
+// @Security BearerAuth
+// @Router /api/parsing-sheets/validate [post]
+func (server *Server) validateSheets(ctx *gin.Context) {
+	var req ValidateSheetsParamRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if _, err := util.GetSheetID(req.Url); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	sheets, err := util.LoadSpreadsheet(credentials, req.Url)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	sheets, err = parser.SelectSheets(sheets, parser.Selection{
+		IgnorePrefix: server.config.SheetIgnorePrefix,
+		Sheets:       req.Sheets,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusOK, newValidateSheetsParamResponse(parser.ErrorIssues(err)))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newValidateSheetsParamResponse(parser.Validate(sheets)))
+}
+
+func newValidateSheetsParamResponse(issues []parser.Issue) ValidateSheetsParamResponse {
+	resp := ValidateSheetsParamResponse{Issues: issues}
+	for _, issue := range issues {
+		switch issue.Severity {
+		case parser.SeverityError:
+			resp.Errors++
+		case parser.SeverityWarning:
+			resp.Warnings++
+		}
+	}
+	resp.Valid = resp.Errors == 0
+
+	return resp
+}
@@ -0,0 +1,105 @@
+package broker
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	reconnectDelay    = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+type connection struct {
+	mu      sync.RWMutex
+	conn    *amqp091.Connection
+	channel *amqp091.Channel
+	// ready is closed while connected and replaced when the connection drops
+	ready chan struct{}
+}
+
+// connect dials the broker, opens a channel and returns the notifications
+// fired when either of them closes.
+func (rmq *RabbitMq) connect() (chan *amqp091.Error, chan *amqp091.Error, error) {
+	conn, err := amqp091.Dial(rmq.source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	connClosed := conn.NotifyClose(make(chan *amqp091.Error, 1))
+	channelClosed := channel.NotifyClose(make(chan *amqp091.Error, 1))
+
+	rmq.mu.Lock()
+	rmq.conn = conn
+	rmq.channel = channel
+	close(rmq.ready)
+	rmq.mu.Unlock()
+
+	return connClosed, channelClosed, nil
+}
+
+// watch waits for the connection or channel to close and reconnects with
+// exponential backoff. Consumers waiting on ready register again afterwards.
+func (rmq *RabbitMq) watch(connClosed, channelClosed chan *amqp091.Error) {
+	for {
+		select {
+		case err := <-connClosed:
+			log.Printf("rabbitmq connection closed: %v", err)
+		case err := <-channelClosed:
+			log.Printf("rabbitmq channel closed: %v", err)
+		}
+
+		rmq.mu.Lock()
+		rmq.ready = make(chan struct{})
+		conn := rmq.conn
+		rmq.mu.Unlock()
+
+		if !conn.IsClosed() {
+			conn.Close()
+		}
+
+		delay := reconnectDelay
+		for {
+			var err error
+			connClosed, channelClosed, err = rmq.connect()
+			if err == nil {
+				log.Printf("rabbitmq reconnected")
+				break
+			}
+
+			log.Printf("can't reconnect to rabbitmq, retrying in %s: %v", delay, err)
+			time.Sleep(delay)
+			delay = min(delay*2, maxReconnectDelay)
+		}
+	}
+}
+
+func (rmq *RabbitMq) currentChannel() *amqp091.Channel {
+	rmq.mu.RLock()
+	defer rmq.mu.RUnlock()
+	return rmq.channel
+}
+
+func (rmq *RabbitMq) readyNotify() chan struct{} {
+	rmq.mu.RLock()
+	defer rmq.mu.RUnlock()
+	return rmq.ready
+}
+
+func (rmq *RabbitMq) IsConnected() bool {
+	select {
+	case <-rmq.readyNotify():
+		return !rmq.currentChannel().IsClosed()
+	default:
+		return false
+	}
+}
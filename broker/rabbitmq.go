@@ -5,63 +5,154 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
-	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	db "github.com/online-tryout/parsing-sheets-api/db/sqlc"
+	"github.com/online-tryout/parsing-sheets-api/parser"
 	"github.com/online-tryout/parsing-sheets-api/util"
 	"github.com/rabbitmq/amqp091-go"
 )
 
+const (
+	ParsingSheetsQueue = "parsing-sheets-queue"
+
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
 type RabbitMq struct {
-	Channel *amqp091.Channel
-	Config  *util.Config
+	connection
+	Config     *util.Config
+	Store      db.Store
+	JobHandler func(msg Message, final bool) (*Result, error)
+	source     string
 }
 
 type Message struct {
-	ProcessID string `json:"processId"`
-	EndedAt   string `json:"endedAt"`
-	Price     string `json:"price"`
-	StartedAt string `json:"startedAt"`
-	Status    string `json:"status"`
-	Title     string `json:"title"`
-	URL       string `json:"url"`
+	ProcessID   string `json:"processId"`
+	JobID       string `json:"jobId,omitempty"`
+	EndedAt     string `json:"endedAt"`
+	Price       string `json:"price"`
+	StartedAt   string `json:"startedAt"`
+	Status      string `json:"status"`
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Duration    int32  `json:"duration,omitempty"`
+	URL         string `json:"url"`
+	// Sheets lists the titles or gids of the sheets to import, all when empty
+	Sheets []string `json:"sheets,omitempty"`
+	// FileName is set for an uploaded file, which is imported instead of URL.
+	// The file itself is stored with the import job to keep messages small.
+	FileName string `json:"fileName,omitempty"`
 }
 
-func NewRabbitMq(source string, config *util.Config) (*RabbitMq, error) {
-	con, err := amqp091.Dial(source)
+// LoadSheets reads the uploaded workbook stored with the import job of the
+// message, or fetches the Google spreadsheet at its URL.
+func (msg Message) LoadSheets(ctx context.Context, q db.Querier) ([]util.SheetData, error) {
+	if len(msg.FileName) == 0 {
+		return util.LoadSpreadsheet(credentials, msg.URL)
+	}
+
+	jobID, err := uuid.Parse(msg.JobID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("uploaded file %s has no import job: %v", msg.FileName, err)
 	}
 
-	rcon, err := con.Channel()
+	file, err := q.GetImportJobFile(ctx, jobID)
 	if err != nil {
 		return nil, err
 	}
+	return util.ReadWorkbook(msg.FileName, file.Content)
+}
 
-	return &RabbitMq{
-		Channel: rcon,
-		Config: config,
-	}, nil
+// WithMetadata fills the fields left empty in the message with the values
+// read from the README sheet, so the request always takes precedence.
+func (msg Message) WithMetadata(meta parser.Metadata) Message {
+	fill := func(field *string, value string) {
+		if len(*field) == 0 {
+			*field = value
+		}
+	}
+
+	fill(&msg.Title, meta.Title)
+	fill(&msg.Price, meta.Price)
+	fill(&msg.Status, meta.Status)
+	fill(&msg.StartedAt, meta.StartedAt)
+	fill(&msg.EndedAt, meta.EndedAt)
+	fill(&msg.Description, meta.Description)
+	if msg.Duration == 0 {
+		msg.Duration = meta.Duration
+	}
+	return msg
 }
 
-func (rmq *RabbitMq) PublishEvent(queue string, msg []byte) error {
-	q, err := rmq.Channel.QueueDeclare(queue, true, false, false, false, nil)
+// Validate checks that the message is complete once it has been filled with
+// the README sheet and returns the start and end of the tryout.
+func (msg Message) Validate() (time.Time, time.Time, error) {
+	required := []struct{ name, value string }{
+		{"title", msg.Title},
+		{"price", msg.Price},
+		{"status", msg.Status},
+		{"startedAt", msg.StartedAt},
+		{"endedAt", msg.EndedAt},
+	}
+	for _, field := range required {
+		if len(field.value) == 0 {
+			return time.Time{}, time.Time{}, fmt.Errorf("%s is missing, set it in the request or the README sheet", field.name)
+		}
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, msg.StartedAt)
 	if err != nil {
-		return err
+		return time.Time{}, time.Time{}, err
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	endedAt, err := time.Parse(time.RFC3339, msg.EndedAt)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+
+	return startedAt, endedAt, nil
+}
+
+func NewRabbitMq(source string, config *util.Config, store db.Store) (*RabbitMq, error) {
+	rmq := &RabbitMq{
+		connection: connection{ready: make(chan struct{})},
+		Config:     config,
+		Store:      store,
+		source:     source,
+	}
+
+	connClosed, channelClosed, err := rmq.connect()
+	if err != nil {
+		return nil, err
+	}
+	go rmq.watch(connClosed, channelClosed)
+
+	return rmq, nil
+}
 
-	publishedMsg := amqp091.Publishing{
+func (rmq *RabbitMq) PublishEvent(queue string, msg []byte) error {
+	return rmq.publish(queue, amqp091.Publishing{
 		ContentType:  "application/json",
 		DeliveryMode: 2,
 		Body:         msg,
+	})
+}
+
+func (rmq *RabbitMq) publish(queue string, publishedMsg amqp091.Publishing) error {
+	channel := rmq.currentChannel()
+	q, err := channel.QueueDeclare(queue, true, false, false, false, nil)
+	if err != nil {
+		return err
 	}
 
-	err = rmq.Channel.PublishWithContext(ctx, "", q.Name, false, false, publishedMsg)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = channel.PublishWithContext(ctx, "", q.Name, false, false, publishedMsg)
 	if err != nil {
 		return err
 	}
@@ -69,217 +160,213 @@ func (rmq *RabbitMq) PublishEvent(queue string, msg []byte) error {
 	return nil
 }
 
+// ConsumeEvent keeps consuming queue for the lifetime of the process. When
+// the connection drops it waits for the reconnect and registers again.
 func (rmq *RabbitMq) ConsumeEvent(queue string) error {
-	q, err := rmq.Channel.QueueDeclare(queue, true, false, false, false, nil)
-	if err != nil {
-		return err
+	for {
+		<-rmq.readyNotify()
+
+		msgs, err := rmq.consume(queue)
+		if err != nil {
+			log.Printf("can't consume %s, retrying in %s: %v", queue, reconnectDelay, err)
+			time.Sleep(reconnectDelay)
+			continue
+		}
+
+		for msg := range msgs {
+			rmq.handleDelivery(queue, msg)
+		}
+		log.Printf("stopped consuming %s, waiting for rabbitmq", queue)
 	}
+}
 
-	msgs, err := rmq.Channel.Consume(q.Name, "", false, false, false, false, nil)
+func (rmq *RabbitMq) consume(queue string) (<-chan amqp091.Delivery, error) {
+	channel := rmq.currentChannel()
+
+	q, err := channel.QueueDeclare(queue, true, false, false, false, nil)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	for msg := range msgs {
-		err := rmq.handleMessage(msg.Body)
-		if err != nil {
-			msg.Nack(false, true)
-			return err
-		}
-		msg.Ack(false)
+	err = rmq.declareDeadLetter(channel, queue)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return channel.Consume(q.Name, "", false, false, false, false, nil)
 }
 
-func (rmq *RabbitMq) handleMessage(body []byte) error {
+// handleDelivery never stops the consumer: a failed message is scheduled for
+// another attempt or, once the attempts are used up, dead-lettered.
+func (rmq *RabbitMq) handleDelivery(queue string, delivery amqp091.Delivery) {
 	var msg Message
 
-	err := json.Unmarshal(body, &msg)
+	err := json.Unmarshal(delivery.Body, &msg)
 	if err != nil {
-		return err
+		rmq.settle(delivery, rmq.deadLetter(queue, delivery, err))
+		return
 	}
 
-	_, err = rmq.parsingSheets(msg)
-	return err
-}
+	attempt := retryCount(delivery) + 1
+	final := attempt >= rmq.Config.RabbitMaxAttempts
 
-const (
-	credentials = "sheets-key.json"
-)
+	result, err := rmq.handleMessage(msg, final)
+	if err == nil {
+		rmq.recordResult(result)
+		rmq.publishResult(result)
+		rmq.settle(delivery, nil)
+		return
+	}
+
+	if !final {
+		log.Printf("attempt %d of process %s failed, retrying: %v", attempt, msg.ProcessID, err)
+		rmq.settle(delivery, rmq.retry(queue, delivery, attempt))
+		return
+	}
 
-type OptionResponse struct {
-	ID          uuid.UUID `json:"id"`
-	QuestionId  uuid.UUID `json:"questionId"`
-	Content     string    `json:"content"`
-	IsTrue      bool      `json:"isTrue"`
-	OptionOrder int       `json:"optionOrder"`
-	UpdatedAt   time.Time `json:"updatedAt"`
-	CreatedAt   time.Time `json:"createdAt"`
+	log.Printf("attempt %d of process %s failed, dead-lettering: %v", attempt, msg.ProcessID, err)
+	result = NewFailedResult(msg.ProcessID, err)
+	rmq.recordResult(result)
+	rmq.publishResult(result)
+	rmq.settle(delivery, rmq.deadLetter(queue, delivery, err))
 }
 
-type QuestionResponse struct {
-	ID            uuid.UUID        `json:"id"`
-	Content       string           `json:"content"`
-	ModuleId      uuid.UUID        `json:"moduleId"`
-	QuestionOrder int              `json:"questionOrder"`
-	UpdatedAt     time.Time        `json:"updatedAt"`
-	CreatedAt     time.Time        `json:"createdAt"`
-	Options       []OptionResponse `json:"options"`
+func (rmq *RabbitMq) handleMessage(msg Message, final bool) (*Result, error) {
+	// a redelivered message is answered with the outcome of its first run
+	result, err := rmq.processedResult(msg.ProcessID)
+	if err != nil || result != nil {
+		if result != nil {
+			log.Printf("process %s was already handled, replaying its result", msg.ProcessID)
+		}
+		return result, err
+	}
+
+	// import jobs are created by the HTTP server, which also tracks their status
+	if len(msg.JobID) > 0 && rmq.JobHandler != nil {
+		return rmq.JobHandler(msg, final)
+	}
+
+	return rmq.parsingSheets(msg)
 }
 
-type ModuleResponse struct {
-	ID          uuid.UUID          `json:"id"`
-	Title       string             `json:"title"`
-	TryoutId    uuid.UUID          `json:"tryoutId"`
-	ModuleOrder int                `json:"moduleOrder"`
-	UpdatedAt   time.Time          `json:"updatedAt"`
-	CreatedAt   time.Time          `json:"createdAt"`
-	Questions   []QuestionResponse `json:"questions"`
+func (rmq *RabbitMq) publishResult(result *Result) {
+	if len(result.ProcessID) == 0 {
+		return
+	}
+
+	if err := rmq.PublishResult(result); err != nil {
+		log.Printf("can't publish result of process %s: %v", result.ProcessID, err)
+	}
 }
 
-type ParsingSheetsParamResponse struct {
-	ID        uuid.UUID        `json:"id"`
-	Title     string           `json:"title"`
-	Price     string           `json:"price"`
-	Status    string           `json:"status"`
-	StartedAt time.Time        `json:"startedAt"`
-	EndedAt   time.Time        `json:"endedAt"`
-	UpdatedAt time.Time        `json:"updatedAt"`
-	CreatedAt time.Time        `json:"createdAt"`
-	Modules   []ModuleResponse `json:"modules"`
+const (
+	credentials = "sheets-key.json"
+)
+
+type dbServiceResponse struct {
+	ID *uuid.UUID `json:"id"`
 }
 
 type CreateTryoutParams struct {
-	Title     string               `json:"title"`
-	Price     string               `json:"price"`
-	Status    string               `json:"status"`
-	StartedAt time.Time            `json:"startedAt"`
-	EndedAt   time.Time            `json:"endedAt"`
-	Modules   []CreateModuleParams `json:"modules"`
+	Title       string               `json:"title"`
+	Price       string               `json:"price"`
+	Status      string               `json:"status"`
+	StartedAt   time.Time            `json:"startedAt"`
+	EndedAt     time.Time            `json:"endedAt"`
+	Description string               `json:"description"`
+	Duration    *int32               `json:"duration"`
+	Modules     []CreateModuleParams `json:"modules"`
 }
 
 type CreateModuleParams struct {
-	Title       string                 `json:"title"`
-	ModuleOrder int32                  `json:"moduleOrder"`
-	Questions   []CreateQuestionParams `json:"questions"`
+	Title        string                 `json:"title"`
+	ModuleOrder  int32                  `json:"moduleOrder"`
+	Duration     *int32                 `json:"duration"`
+	Instructions string                 `json:"instructions"`
+	Description  string                 `json:"description"`
+	Passages     []CreatePassageParams  `json:"passages"`
+	Questions    []CreateQuestionParams `json:"questions"`
+}
+
+type CreatePassageParams struct {
+	Content      string `json:"content"`
+	PassageOrder int32  `json:"passageOrder"`
 }
 
 type CreateQuestionParams struct {
-	Content       string               `json:"content"`
-	QuestionOrder int32                `json:"questionOrder"`
-	Options       []CreateOptionParams `json:"options"`
+	Content          string                       `json:"content"`
+	QuestionOrder    int32                        `json:"questionOrder"`
+	Type             string                       `json:"type"`
+	CaseSensitive    bool                         `json:"caseSensitive"`
+	NumericAnswer    *float64                     `json:"numericAnswer"`
+	NumericTolerance *float64                     `json:"numericTolerance"`
+	Explanation      string                       `json:"explanation"`
+	Points           float64                      `json:"points"`
+	WrongPenalty     float64                      `json:"wrongPenalty"`
+	BlankScore       float64                      `json:"blankScore"`
+	PassageOrder     *int32                       `json:"passageOrder"`
+	Options          []CreateOptionParams         `json:"options"`
+	AcceptedAnswers  []CreateAcceptedAnswerParams `json:"acceptedAnswers"`
+	Tags             []CreateTagParams            `json:"tags"`
 }
 
 type CreateOptionParams struct {
+	Content     string   `json:"content"`
+	IsTrue      bool     `json:"isTrue"`
+	OptionOrder int32    `json:"optionOrder"`
+	Explanation string   `json:"explanation"`
+	Points      *float64 `json:"points"`
+}
+
+type CreateAcceptedAnswerParams struct {
 	Content     string `json:"content"`
-	IsTrue      bool   `json:"isTrue"`
-	OptionOrder int32  `json:"optionOrder"`
+	AnswerOrder int32  `json:"answerOrder"`
 }
 
-func (rmq *RabbitMq) parsingSheets(msg Message) (*ParsingSheetsParamResponse, error) {
+type CreateTagParams struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
 
-	startedAtTime, err := time.Parse(time.RFC3339, msg.StartedAt)
+// parsingSheets imports the spreadsheet through the DB service. Problems with
+// the message or the spreadsheet itself are returned as a failed result, since
+// handling the message again would not fix them.
+func (rmq *RabbitMq) parsingSheets(msg Message) (*Result, error) {
+	sheets, err := msg.LoadSheets(context.Background(), rmq.Store)
 	if err != nil {
 		return nil, err
 	}
 
-	endedAtTime, err := time.Parse(time.RFC3339, msg.EndedAt)
+	sheets, err = parser.SelectSheets(sheets, parser.Selection{
+		IgnorePrefix: rmq.Config.SheetIgnorePrefix,
+		Sheets:       msg.Sheets,
+	})
 	if err != nil {
-		return nil, err
-	}
-
-	arg := CreateTryoutParams{
-		Title:     msg.Title,
-		Price:     msg.Price,
-		Status:    msg.Status,
-		StartedAt: startedAtTime,
-		EndedAt:   endedAtTime,
+		return NewFailedResult(msg.ProcessID, err), nil
 	}
 
-	client, err := util.GetSheetsClient(credentials)
+	tree, err := parser.Parse(sheets)
 	if err != nil {
-		return nil, err
+		return NewFailedResult(msg.ProcessID, err), nil
 	}
 
-	spreadsheetID, err := util.GetSheetID(msg.URL)
-	if err != nil {
-		return nil, err
-	}
-	spreadsheetInfo, err := util.GetSpreadsheetInfo(client, spreadsheetID)
+	msg = msg.WithMetadata(tree.Metadata)
+	startedAtTime, endedAtTime, err := msg.Validate()
 	if err != nil {
-		return nil, err
+		return NewFailedResult(msg.ProcessID, err), nil
 	}
 
-	for moduleOrder, sheet := range spreadsheetInfo.Sheets {
-		title := sheet.Properties.Title
-		row := sheet.Properties.GridProperties.RowCount
-		col := sheet.Properties.GridProperties.ColumnCount
-
-		if title == "README" {
-			continue
-		}
-
-		moduleArg := CreateModuleParams{
-			Title:       title,
-			ModuleOrder: int32(moduleOrder),
-		}
-
-		data, err := util.FetchData(client, spreadsheetID, sheet.Properties.Title, fmt.Sprintf("A2:%s%d", util.NumberToColumnLetter(col), row))
-		if err != nil {
-			return nil, err
-		}
-
-		var sheetsReader util.SheetsRowReader
-		var number string
-		var question string
-		var answer string
-		var option string
-
-		for i, row := range data {
-			for j, cell := range row {
-				switch j {
-				case 0:
-					number = fmt.Sprint(cell)
-				case 1:
-					question = fmt.Sprint(cell)
-				case 2:
-					answer = fmt.Sprint(cell)
-				case 3:
-					option = fmt.Sprint(cell)
-				}
-			}
-
-			if len(number) == 0 && len(question) == 0 && len(answer) == 0 && len(option) > 0 {
-				sheetsReader.Option = append(sheetsReader.Option, option)
-			} else if len(number) > 0 && len(question) > 0 && len(answer) > 0 && len(option) > 0 {
-				if !sheetsReader.IsEmpty() {
-					questions, err := createQuestionAndOption(&sheetsReader)
-					if err != nil {
-						return nil, err
-					}
-					moduleArg.Questions = append(moduleArg.Questions, *questions)
-				}
-				sheetsReader = util.SheetsRowReader{
-					Number:   number,
-					Question: question,
-					Answer:   answer,
-					Option:   []string{option},
-				}
-			} else {
-				return nil, fmt.Errorf("data format was wrong: number %s, question %s, answer %s, option %s", number, question, answer, option)
-			}
-
-			if i == len(data)-1 {
-				questions, err := createQuestionAndOption(&sheetsReader)
-				if err != nil {
-					return nil, err
-				}
-				moduleArg.Questions = append(moduleArg.Questions, *questions)
-			}
-		}
-
-		arg.Modules = append(arg.Modules, moduleArg)
+	arg := CreateTryoutParams{
+		Title:       msg.Title,
+		Price:       msg.Price,
+		Status:      msg.Status,
+		StartedAt:   startedAtTime,
+		EndedAt:     endedAtTime,
+		Description: msg.Description,
+		Modules:     newCreateModuleParams(tree.Modules),
+	}
+	if msg.Duration > 0 {
+		arg.Duration = &msg.Duration
 	}
 
 	// Call DB Service to save arg to it
@@ -294,6 +381,9 @@ func (rmq *RabbitMq) parsingSheets(msg Message) (*ParsingSheetsParamResponse, er
 		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
+	// a redelivered message must not create the tryout twice when the DB
+	// service saved it but the response never came back
+	req.Header.Set(idempotencyKeyHeader, msg.ProcessID)
 
 	httpClient := &http.Client{}
 	resp, err := httpClient.Do(req)
@@ -306,32 +396,87 @@ func (rmq *RabbitMq) parsingSheets(msg Message) (*ParsingSheetsParamResponse, er
 		return nil, fmt.Errorf("failed to call API: %s", resp.Status)
 	}
 
-	return &ParsingSheetsParamResponse{}, nil
+	// the tryout is saved even when its id can't be read back, so the
+	// result is still a success, just without the id
+	var tryout dbServiceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tryout); err != nil {
+		log.Printf("can't decode DB service response for process %s: %v", msg.ProcessID, err)
+		tryout.ID = nil
+	}
+
+	return NewSucceededResult(msg.ProcessID, tryout.ID, tree), nil
 }
 
-func createQuestionAndOption(sheetsReader *util.SheetsRowReader) (*CreateQuestionParams, error) {
-	order, err := strconv.Atoi(sheetsReader.Number)
-	if err != nil {
-		return nil, err
-	}
-	questionArg := CreateQuestionParams{
-		Content:       sheetsReader.Question,
-		QuestionOrder: int32(order),
-	}
+func newCreateModuleParams(modules []parser.Module) []CreateModuleParams {
+	result := []CreateModuleParams{}
+	for _, module := range modules {
+		moduleArg := CreateModuleParams{
+			Title:        module.Title,
+			ModuleOrder:  module.ModuleOrder,
+			Instructions: module.Instructions,
+			Description:  module.Description,
+			Passages:     []CreatePassageParams{},
+			Questions:    []CreateQuestionParams{},
+		}
+		if module.Duration > 0 {
+			duration := module.Duration
+			moduleArg.Duration = &duration
+		}
+
+		for _, passage := range module.Passages {
+			moduleArg.Passages = append(moduleArg.Passages, CreatePassageParams{
+				Content:      passage.Content,
+				PassageOrder: passage.PassageOrder,
+			})
+		}
 
-	var options []CreateOptionParams
+		for _, question := range module.Questions {
+			questionArg := CreateQuestionParams{
+				Content:          question.Content,
+				QuestionOrder:    question.QuestionOrder,
+				Type:             question.Type,
+				CaseSensitive:    question.CaseSensitive,
+				NumericAnswer:    question.NumericAnswer,
+				NumericTolerance: question.NumericTolerance,
+				Explanation:      question.Explanation,
+				Points:           question.Points,
+				WrongPenalty:     question.WrongPenalty,
+				BlankScore:       question.BlankScore,
+				PassageOrder:     question.PassageOrder,
+				Options:          []CreateOptionParams{},
+				AcceptedAnswers:  []CreateAcceptedAnswerParams{},
+				Tags:             []CreateTagParams{},
+			}
+
+			for _, option := range question.Options {
+				questionArg.Options = append(questionArg.Options, CreateOptionParams{
+					Content:     option.Content,
+					IsTrue:      option.IsTrue,
+					OptionOrder: option.OptionOrder,
+					Explanation: option.Explanation,
+					Points:      option.Points,
+				})
+			}
+
+			for answerOrder, answer := range question.AcceptedAnswers {
+				questionArg.AcceptedAnswers = append(questionArg.AcceptedAnswers, CreateAcceptedAnswerParams{
+					Content:     answer,
+					AnswerOrder: int32(answerOrder) + 1,
+				})
+			}
+
+			for _, tag := range question.Tags {
+				questionArg.Tags = append(questionArg.Tags, CreateTagParams{
+					Type: tag.Type,
+					Name: tag.Name,
+				})
+			}
 
-	for optionOrder, option := range sheetsReader.Option {
-		optionArg := CreateOptionParams{
-			Content:     option,
-			IsTrue:      option == sheetsReader.Option[int(sheetsReader.Answer[0])-int('A')],
-			OptionOrder: int32(optionOrder) + 1,
+			moduleArg.Questions = append(moduleArg.Questions, questionArg)
 		}
 
-		options = append(options, optionArg)
+		result = append(result, moduleArg)
 	}
 
-	questionArg.Options = options
-
-	return &questionArg, nil
+	return result
 }
@@ -0,0 +1,122 @@
+package broker
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/google/uuid"
+	db "github.com/online-tryout/parsing-sheets-api/db/sqlc"
+	"github.com/online-tryout/parsing-sheets-api/parser"
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	ResultSucceeded = "succeeded"
+	ResultFailed    = "failed"
+)
+
+// Result is published to the reply queue once a message has been handled,
+// so whoever sent the message can learn what happened to its ProcessID.
+type Result struct {
+	ProcessID string         `json:"processId"`
+	Status    string         `json:"status"`
+	TryoutID  *uuid.UUID     `json:"tryoutId,omitempty"`
+	Modules   int            `json:"modules"`
+	Questions int            `json:"questions"`
+	Options   int            `json:"options"`
+	Errors    []parser.Issue `json:"errors"`
+}
+
+// NewSucceededResult summarizes the imported tree. tryoutID is nil when the
+// id of the created tryout isn't known.
+func NewSucceededResult(processID string, tryoutID *uuid.UUID, tree *parser.Tryout) *Result {
+	result := &Result{
+		ProcessID: processID,
+		Status:    ResultSucceeded,
+		TryoutID:  tryoutID,
+		Modules:   len(tree.Modules),
+		Errors:    []parser.Issue{},
+	}
+
+	for _, module := range tree.Modules {
+		result.Questions += len(module.Questions)
+		for _, question := range module.Questions {
+			result.Options += len(question.Options)
+		}
+	}
+
+	return result
+}
+
+func NewFailedResult(processID string, err error) *Result {
+	return &Result{
+		ProcessID: processID,
+		Status:    ResultFailed,
+		Errors:    parser.ErrorIssues(err),
+	}
+}
+
+func (rmq *RabbitMq) PublishResult(result *Result) error {
+	body, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return rmq.publish(rmq.Config.RabbitReplyQueue, amqp091.Publishing{
+		ContentType:   "application/json",
+		DeliveryMode:  2,
+		CorrelationId: result.ProcessID,
+		Body:          body,
+	})
+}
+
+// processedResult returns the stored result of a ProcessID that was already
+// handled, or nil if it was not.
+func (rmq *RabbitMq) processedResult(processID string) (*Result, error) {
+	if len(processID) == 0 {
+		return nil, nil
+	}
+
+	processed, err := rmq.Store.GetProcessedMessage(context.Background(), processID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var result Result
+	if err := json.Unmarshal(processed.Result, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (rmq *RabbitMq) recordResult(result *Result) {
+	if err := RecordResult(context.Background(), rmq.Store, result); err != nil {
+		log.Printf("can't record result of process %s: %v", result.ProcessID, err)
+	}
+}
+
+// RecordResult stores the result of a ProcessID using q, so a handler can
+// record it in the same transaction as the work it describes. A result that
+// was already recorded is kept.
+func RecordResult(ctx context.Context, q db.Querier, result *Result) error {
+	if len(result.ProcessID) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	return q.CreateProcessedMessage(ctx, db.CreateProcessedMessageParams{
+		ProcessId: result.ProcessID,
+		Status:    result.Status,
+		Result:    body,
+	})
+}
@@ -0,0 +1,134 @@
+package broker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	retryCountHeader = "x-retry-count"
+	lastErrorHeader  = "x-last-error"
+)
+
+func deadLetterExchange(queue string) string {
+	return queue + ".dlx"
+}
+
+func deadLetterQueue(queue string) string {
+	return queue + ".dead"
+}
+
+func retryQueue(queue string, delay time.Duration) string {
+	return fmt.Sprintf("%s.retry.%s", queue, delay)
+}
+
+func (rmq *RabbitMq) declareDeadLetter(channel *amqp091.Channel, queue string) error {
+	exchange := deadLetterExchange(queue)
+
+	err := channel.ExchangeDeclare(exchange, "fanout", true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	q, err := channel.QueueDeclare(deadLetterQueue(queue), true, false, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	return channel.QueueBind(q.Name, "", exchange, false, nil)
+}
+
+// retryDelay doubles the configured delay for every attempt already made.
+func (rmq *RabbitMq) retryDelay(attempt int) time.Duration {
+	delay := rmq.Config.RabbitRetryDelay
+	for i := 1; i < attempt && delay < rmq.Config.RabbitMaxRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > rmq.Config.RabbitMaxRetryDelay {
+		delay = rmq.Config.RabbitMaxRetryDelay
+	}
+	return delay
+}
+
+// retry parks the message in a queue without consumers whose TTL equals the
+// backoff delay; once expired, RabbitMQ dead-letters it back to queue. Every
+// delay gets its own retry queue so a long delay never holds up a short one.
+func (rmq *RabbitMq) retry(queue string, delivery amqp091.Delivery, attempt int) error {
+	delay := rmq.retryDelay(attempt)
+
+	q, err := rmq.currentChannel().QueueDeclare(retryQueue(queue, delay), true, false, false, false, amqp091.Table{
+		"x-message-ttl":             delay.Milliseconds(),
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": queue,
+	})
+	if err != nil {
+		return err
+	}
+
+	headers := copyHeaders(delivery.Headers)
+	headers[retryCountHeader] = int32(attempt)
+
+	return rmq.republish("", q.Name, delivery, headers)
+}
+
+func (rmq *RabbitMq) deadLetter(queue string, delivery amqp091.Delivery, cause error) error {
+	headers := copyHeaders(delivery.Headers)
+	headers[lastErrorHeader] = cause.Error()
+
+	return rmq.republish(deadLetterExchange(queue), "", delivery, headers)
+}
+
+func (rmq *RabbitMq) republish(exchange, key string, delivery amqp091.Delivery, headers amqp091.Table) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return rmq.currentChannel().PublishWithContext(ctx, exchange, key, false, false, amqp091.Publishing{
+		Headers:       headers,
+		ContentType:   delivery.ContentType,
+		DeliveryMode:  amqp091.Persistent,
+		CorrelationId: delivery.CorrelationId,
+		MessageId:     delivery.MessageId,
+		Body:          delivery.Body,
+	})
+}
+
+// settle acknowledges a delivery once it has been handed off. If that failed
+// the delivery is requeued so the message is never lost.
+func (rmq *RabbitMq) settle(delivery amqp091.Delivery, err error) {
+	if err != nil {
+		log.Printf("can't hand off message, requeueing it: %v", err)
+		if err := delivery.Nack(false, true); err != nil {
+			log.Printf("can't requeue message: %v", err)
+		}
+		return
+	}
+
+	if err := delivery.Ack(false); err != nil {
+		log.Printf("can't acknowledge message: %v", err)
+	}
+}
+
+func retryCount(delivery amqp091.Delivery) int {
+	switch count := delivery.Headers[retryCountHeader].(type) {
+	case int32:
+		return int(count)
+	case int64:
+		return int(count)
+	case int:
+		return count
+	}
+	return 0
+}
+
+func copyHeaders(headers amqp091.Table) amqp091.Table {
+	result := amqp091.Table{}
+	for key, value := range headers {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "importJobs";
\ No newline at end of file
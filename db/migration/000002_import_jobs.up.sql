@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "importJobs" (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  url TEXT NOT NULL,
+  status VARCHAR(255) NOT NULL DEFAULT 'queued',
+  "totalModules" INT NOT NULL DEFAULT 0,
+  "importedModules" INT NOT NULL DEFAULT 0,
+  progress JSONB NOT NULL DEFAULT '[]',
+  "tryoutId" UUID,
+  errors JSONB NOT NULL DEFAULT '[]',
+  result JSONB NOT NULL DEFAULT '{}',
+  "startedAt" TIMESTAMP WITH TIME ZONE,
+  "finishedAt" TIMESTAMP WITH TIME ZONE,
+  "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE "importJobs" ADD CONSTRAINT fk_importJobs_tryouts FOREIGN KEY ("tryoutId") REFERENCES tryouts(id);
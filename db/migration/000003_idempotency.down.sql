@@ -0,0 +1,3 @@
+ALTER TABLE "importJobs" DROP CONSTRAINT IF EXISTS uq_importJobs_idempotencyKey;
+ALTER TABLE "importJobs" DROP COLUMN IF EXISTS "idempotencyKey";
+DROP TABLE IF EXISTS "processedMessages";
\ No newline at end of file
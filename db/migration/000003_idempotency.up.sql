@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS "processedMessages" (
+  "processId" VARCHAR(255) PRIMARY KEY,
+  status VARCHAR(255) NOT NULL,
+  result JSONB NOT NULL,
+  "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE "importJobs" ADD COLUMN "idempotencyKey" VARCHAR(255);
+ALTER TABLE "importJobs" ADD CONSTRAINT uq_importJobs_idempotencyKey UNIQUE ("idempotencyKey");
@@ -0,0 +1 @@
+ALTER TABLE questions ADD COLUMN type VARCHAR(255) NOT NULL DEFAULT 'single';
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS chk_questions_type;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE questions ADD CONSTRAINT chk_questions_type CHECK (type IN ('single', 'multiple', 'complex'));
\ No newline at end of file
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS "acceptedAnswers";
+
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS chk_questions_type;
+ALTER TABLE questions ADD CONSTRAINT chk_questions_type CHECK (type IN ('single', 'multiple', 'complex'));
+
+ALTER TABLE questions DROP COLUMN IF EXISTS "numericTolerance";
+ALTER TABLE questions DROP COLUMN IF EXISTS "numericAnswer";
+ALTER TABLE questions DROP COLUMN IF EXISTS "caseSensitive";
\ No newline at end of file
@@ -0,0 +1,17 @@
+ALTER TABLE questions ADD COLUMN "caseSensitive" BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE questions ADD COLUMN "numericAnswer" DOUBLE PRECISION;
+ALTER TABLE questions ADD COLUMN "numericTolerance" DOUBLE PRECISION;
+
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS chk_questions_type;
+ALTER TABLE questions ADD CONSTRAINT chk_questions_type CHECK (type IN ('single', 'multiple', 'complex', 'short', 'numeric'));
+
+CREATE TABLE IF NOT EXISTS "acceptedAnswers" (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "questionId" UUID NOT NULL,
+  content TEXT NOT NULL,
+  "answerOrder" INT,
+  "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE "acceptedAnswers" ADD CONSTRAINT fk_acceptedAnswers_questions FOREIGN KEY ("questionId") REFERENCES questions(id);
\ No newline at end of file
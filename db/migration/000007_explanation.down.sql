@@ -0,0 +1,2 @@
+ALTER TABLE options DROP COLUMN IF EXISTS explanation;
+ALTER TABLE questions DROP COLUMN IF EXISTS explanation;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE questions ADD COLUMN explanation TEXT NOT NULL DEFAULT '';
+ALTER TABLE options ADD COLUMN explanation TEXT NOT NULL DEFAULT '';
\ No newline at end of file
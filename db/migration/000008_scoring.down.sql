@@ -0,0 +1,4 @@
+ALTER TABLE options DROP COLUMN IF EXISTS points;
+ALTER TABLE questions DROP COLUMN IF EXISTS "blankScore";
+ALTER TABLE questions DROP COLUMN IF EXISTS "wrongPenalty";
+ALTER TABLE questions DROP COLUMN IF EXISTS points;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE questions ADD COLUMN points DOUBLE PRECISION NOT NULL DEFAULT 1;
+ALTER TABLE questions ADD COLUMN "wrongPenalty" DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE questions ADD COLUMN "blankScore" DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE options ADD COLUMN points DOUBLE PRECISION;
\ No newline at end of file
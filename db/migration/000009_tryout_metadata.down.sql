@@ -0,0 +1,2 @@
+ALTER TABLE tryouts DROP COLUMN IF EXISTS duration;
+ALTER TABLE tryouts DROP COLUMN IF EXISTS description;
\ No newline at end of file
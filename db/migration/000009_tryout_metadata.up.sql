@@ -0,0 +1,2 @@
+ALTER TABLE tryouts ADD COLUMN description TEXT NOT NULL DEFAULT '';
+ALTER TABLE tryouts ADD COLUMN duration INT;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE modules DROP COLUMN IF EXISTS description;
+ALTER TABLE modules DROP COLUMN IF EXISTS instructions;
+ALTER TABLE modules DROP COLUMN IF EXISTS duration;
\ No newline at end of file
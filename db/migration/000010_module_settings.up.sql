@@ -0,0 +1,3 @@
+ALTER TABLE modules ADD COLUMN duration INT;
+ALTER TABLE modules ADD COLUMN instructions TEXT NOT NULL DEFAULT '';
+ALTER TABLE modules ADD COLUMN description TEXT NOT NULL DEFAULT '';
\ No newline at end of file
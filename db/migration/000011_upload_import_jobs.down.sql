@@ -0,0 +1 @@
+ALTER TABLE "importJobs" DROP COLUMN IF EXISTS "fileName";
\ No newline at end of file
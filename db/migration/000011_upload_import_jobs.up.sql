@@ -0,0 +1 @@
+ALTER TABLE "importJobs" ADD COLUMN "fileName" VARCHAR(255) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS fk_questions_passages;
+ALTER TABLE questions DROP COLUMN IF EXISTS "passageId";
+
+DROP TABLE IF EXISTS passages;
\ No newline at end of file
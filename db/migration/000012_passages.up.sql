@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS passages (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  "moduleId" UUID NOT NULL,
+  content TEXT NOT NULL,
+  "passageOrder" INT,
+  "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE passages ADD CONSTRAINT fk_passages_modules FOREIGN KEY ("moduleId") REFERENCES modules(id);
+
+ALTER TABLE questions ADD COLUMN "passageId" UUID;
+ALTER TABLE questions ADD CONSTRAINT fk_questions_passages FOREIGN KEY ("passageId") REFERENCES passages(id);
\ No newline at end of file
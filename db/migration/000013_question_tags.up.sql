@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS tags (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  type VARCHAR(255) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  UNIQUE (type, name)
+);
+
+ALTER TABLE tags ADD CONSTRAINT chk_tags_type CHECK (type IN ('topic', 'subtopic', 'difficulty', 'learningObjective'));
+
+CREATE TABLE IF NOT EXISTS "questionTags" (
+  "questionId" UUID NOT NULL,
+  "tagId" UUID NOT NULL,
+  "tagOrder" INT,
+  "updatedAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+  PRIMARY KEY ("questionId", "tagId")
+);
+
+ALTER TABLE "questionTags" ADD CONSTRAINT fk_questionTags_questions FOREIGN KEY ("questionId") REFERENCES questions(id);
+ALTER TABLE "questionTags" ADD CONSTRAINT fk_questionTags_tags FOREIGN KEY ("tagId") REFERENCES tags(id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "importJobFiles";
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS "importJobFiles" (
+  "jobId" UUID PRIMARY KEY,
+  content BYTEA NOT NULL,
+  "createdAt" TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE "importJobFiles" ADD CONSTRAINT fk_importJobFiles_importJobs FOREIGN KEY ("jobId") REFERENCES "importJobs"(id);
\ No newline at end of file
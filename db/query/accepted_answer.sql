@@ -0,0 +1,14 @@
+-- name: CreateAcceptedAnswer :one
+INSERT INTO "acceptedAnswers" (
+        content,
+        "questionId",
+        "answerOrder"
+    )
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListAcceptedAnswersByQuestion :many
+SELECT *
+FROM "acceptedAnswers"
+WHERE "questionId" = $1
+ORDER BY "answerOrder", "createdAt";
\ No newline at end of file
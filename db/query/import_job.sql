@@ -0,0 +1,90 @@
+-- name: CreateImportJob :one
+INSERT INTO "importJobs" (
+        url,
+        "idempotencyKey",
+        "fileName"
+    )
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetImportJob :one
+SELECT *
+FROM "importJobs"
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetImportJobByIdempotencyKey :one
+SELECT *
+FROM "importJobs"
+WHERE "idempotencyKey" = $1
+LIMIT 1;
+
+-- name: ReleaseImportJobIdempotencyKey :exec
+-- lets a request with the same key create a new job
+UPDATE "importJobs"
+SET "idempotencyKey" = NULL,
+    "updatedAt" = NOW()
+WHERE id = $1;
+
+-- name: StartImportJob :exec
+-- a retried job starts over from the state left by the previous attempt
+UPDATE "importJobs"
+SET status = 'running',
+    "totalModules" = $2,
+    "importedModules" = 0,
+    progress = $3,
+    errors = '[]',
+    "startedAt" = NOW(),
+    "finishedAt" = NULL,
+    "updatedAt" = NOW()
+WHERE id = $1;
+
+-- name: UpdateImportJobProgress :exec
+UPDATE "importJobs"
+SET "importedModules" = $2,
+    progress = $3,
+    "updatedAt" = NOW()
+WHERE id = $1;
+
+-- name: CompleteImportJob :exec
+UPDATE "importJobs"
+SET status = 'succeeded',
+    "tryoutId" = $2,
+    result = $3,
+    "finishedAt" = NOW(),
+    "updatedAt" = NOW()
+WHERE id = $1;
+
+-- name: RetryImportJob :exec
+-- the tryout of the failed attempt is rolled back, so no module is left
+-- imported until the next attempt
+UPDATE "importJobs"
+SET status = 'retrying',
+    errors = $2,
+    "importedModules" = 0,
+    progress = COALESCE(
+        (
+            SELECT jsonb_agg(jsonb_set(module, '{status}', '"pending"'))
+            FROM jsonb_array_elements(progress) AS module
+        ),
+        '[]'
+    ),
+    "updatedAt" = NOW()
+WHERE id = $1;
+
+-- name: FailImportJob :exec
+-- the tryout is rolled back on failure, so no module is left imported
+UPDATE "importJobs"
+SET status = 'failed',
+    errors = $2,
+    "importedModules" = 0,
+    progress = COALESCE(
+        (
+            SELECT jsonb_agg(jsonb_set(module, '{status}', '"pending"'))
+            FROM jsonb_array_elements(progress) AS module
+        ),
+        '[]'
+    ),
+    "finishedAt" = NOW(),
+    "updatedAt" = NOW()
+WHERE id = $1;
\ No newline at end of file
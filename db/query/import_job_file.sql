@@ -0,0 +1,16 @@
+-- name: CreateImportJobFile :exec
+INSERT INTO "importJobFiles" (
+        "jobId",
+        content
+    )
+VALUES ($1, $2);
+
+-- name: GetImportJobFile :one
+SELECT *
+FROM "importJobFiles"
+WHERE "jobId" = $1
+LIMIT 1;
+
+-- name: DeleteImportJobFile :exec
+DELETE FROM "importJobFiles"
+WHERE "jobId" = $1;
\ No newline at end of file
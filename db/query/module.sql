@@ -2,7 +2,16 @@
 INSERT INTO "modules" (
         title,
         "tryoutId",
-        "moduleOrder"
+        "moduleOrder",
+        duration,
+        instructions,
+        description
     )
-VALUES ($1, $2, $3)
-RETURNING *;
\ No newline at end of file
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListModulesByTryout :many
+SELECT *
+FROM "modules"
+WHERE "tryoutId" = $1
+ORDER BY "moduleOrder", "createdAt";
\ No newline at end of file
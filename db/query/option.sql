@@ -3,7 +3,15 @@ INSERT INTO "options" (
         content,
         "questionId",
         "isTrue",
-        "optionOrder"
+        "optionOrder",
+        explanation,
+        points
     )
-VALUES ($1, $2, $3, $4)
-RETURNING *;
\ No newline at end of file
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: ListOptionsByQuestion :many
+SELECT *
+FROM "options"
+WHERE "questionId" = $1
+ORDER BY "optionOrder", "createdAt";
\ No newline at end of file
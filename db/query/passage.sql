@@ -0,0 +1,14 @@
+-- name: CreatePassage :one
+INSERT INTO "passages" (
+        content,
+        "moduleId",
+        "passageOrder"
+    )
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListPassagesByModule :many
+SELECT *
+FROM "passages"
+WHERE "moduleId" = $1
+ORDER BY "passageOrder", "createdAt";
\ No newline at end of file
@@ -0,0 +1,14 @@
+-- name: GetProcessedMessage :one
+SELECT *
+FROM "processedMessages"
+WHERE "processId" = $1
+LIMIT 1;
+
+-- name: CreateProcessedMessage :exec
+INSERT INTO "processedMessages" (
+        "processId",
+        status,
+        result
+    )
+VALUES ($1, $2, $3)
+ON CONFLICT ("processId") DO NOTHING;
\ No newline at end of file
@@ -2,7 +2,22 @@
 INSERT INTO "questions" (
         content,
         "moduleId",
-        "questionOrder"
+        "questionOrder",
+        type,
+        "caseSensitive",
+        "numericAnswer",
+        "numericTolerance",
+        explanation,
+        points,
+        "wrongPenalty",
+        "blankScore",
+        "passageId"
     )
-VALUES ($1, $2, $3)
-RETURNING *;
\ No newline at end of file
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING *;
+
+-- name: ListQuestionsByModule :many
+SELECT *
+FROM "questions"
+WHERE "moduleId" = $1
+ORDER BY "questionOrder", "createdAt";
\ No newline at end of file
@@ -0,0 +1,24 @@
+-- name: UpsertTag :one
+INSERT INTO "tags" (
+        type,
+        name
+    )
+VALUES ($1, $2)
+ON CONFLICT (type, name) DO UPDATE SET name = EXCLUDED.name
+RETURNING *;
+
+-- name: CreateQuestionTag :one
+INSERT INTO "questionTags" (
+        "questionId",
+        "tagId",
+        "tagOrder"
+    )
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ListTagsByQuestion :many
+SELECT "tags".*
+FROM "tags"
+    JOIN "questionTags" ON "questionTags"."tagId" = "tags".id
+WHERE "questionTags"."questionId" = $1
+ORDER BY "questionTags"."tagOrder", "tags".type;
\ No newline at end of file
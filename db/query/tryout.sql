@@ -4,7 +4,15 @@ INSERT INTO "tryouts" (
         price,
         status,
         "startedAt",
-        "endedAt"
+        "endedAt",
+        description,
+        duration
     )
-VALUES ($1, $2, $3, $4, $5)
-RETURNING *;
\ No newline at end of file
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetTryout :one
+SELECT *
+FROM "tryouts"
+WHERE id = $1
+LIMIT 1;
\ No newline at end of file
@@ -0,0 +1,80 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: accepted_answer.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createAcceptedAnswer = `-- name: CreateAcceptedAnswer :one
+INSERT INTO "acceptedAnswers" (
+        content,
+        "questionId",
+        "answerOrder"
+    )
+VALUES ($1, $2, $3)
+RETURNING id, "questionId", content, "answerOrder", "updatedAt", "createdAt"
+`
+
+type CreateAcceptedAnswerParams struct {
+	Content     string        `json:"content"`
+	QuestionId  uuid.UUID     `json:"questionId"`
+	AnswerOrder sql.NullInt32 `json:"answerOrder"`
+}
+
+func (q *Queries) CreateAcceptedAnswer(ctx context.Context, arg CreateAcceptedAnswerParams) (AcceptedAnswers, error) {
+	row := q.db.QueryRowContext(ctx, createAcceptedAnswer, arg.Content, arg.QuestionId, arg.AnswerOrder)
+	var i AcceptedAnswers
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionId,
+		&i.Content,
+		&i.AnswerOrder,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listAcceptedAnswersByQuestion = `-- name: ListAcceptedAnswersByQuestion :many
+SELECT id, "questionId", content, "answerOrder", "updatedAt", "createdAt"
+FROM "acceptedAnswers"
+WHERE "questionId" = $1
+ORDER BY "answerOrder", "createdAt"
+`
+
+func (q *Queries) ListAcceptedAnswersByQuestion(ctx context.Context, questionid uuid.UUID) ([]AcceptedAnswers, error) {
+	rows, err := q.db.QueryContext(ctx, listAcceptedAnswersByQuestion, questionid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AcceptedAnswers{}
+	for rows.Next() {
+		var i AcceptedAnswers
+		if err := rows.Scan(
+			&i.ID,
+			&i.QuestionId,
+			&i.Content,
+			&i.AnswerOrder,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
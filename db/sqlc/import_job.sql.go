@@ -0,0 +1,247 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: import_job.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+const completeImportJob = `-- name: CompleteImportJob :exec
+UPDATE "importJobs"
+SET status = 'succeeded',
+    "tryoutId" = $2,
+    result = $3,
+    "finishedAt" = NOW(),
+    "updatedAt" = NOW()
+WHERE id = $1
+`
+
+type CompleteImportJobParams struct {
+	ID       uuid.UUID       `json:"id"`
+	TryoutId uuid.NullUUID   `json:"tryoutId"`
+	Result   json.RawMessage `json:"result"`
+}
+
+func (q *Queries) CompleteImportJob(ctx context.Context, arg CompleteImportJobParams) error {
+	_, err := q.db.ExecContext(ctx, completeImportJob, arg.ID, arg.TryoutId, arg.Result)
+	return err
+}
+
+const createImportJob = `-- name: CreateImportJob :one
+INSERT INTO "importJobs" (
+        url,
+        "idempotencyKey",
+        "fileName"
+    )
+VALUES ($1, $2, $3)
+RETURNING id, url, status, "totalModules", "importedModules", progress, "tryoutId", errors, result, "startedAt", "finishedAt", "updatedAt", "createdAt", "idempotencyKey", "fileName"
+`
+
+type CreateImportJobParams struct {
+	Url            string         `json:"url"`
+	IdempotencyKey sql.NullString `json:"idempotencyKey"`
+	FileName       string         `json:"fileName"`
+}
+
+func (q *Queries) CreateImportJob(ctx context.Context, arg CreateImportJobParams) (ImportJobs, error) {
+	row := q.db.QueryRowContext(ctx, createImportJob, arg.Url, arg.IdempotencyKey, arg.FileName)
+	var i ImportJobs
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Status,
+		&i.TotalModules,
+		&i.ImportedModules,
+		&i.Progress,
+		&i.TryoutId,
+		&i.Errors,
+		&i.Result,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.IdempotencyKey,
+		&i.FileName,
+	)
+	return i, err
+}
+
+const failImportJob = `-- name: FailImportJob :exec
+UPDATE "importJobs"
+SET status = 'failed',
+    errors = $2,
+    "importedModules" = 0,
+    progress = COALESCE(
+        (
+            SELECT jsonb_agg(jsonb_set(module, '{status}', '"pending"'))
+            FROM jsonb_array_elements(progress) AS module
+        ),
+        '[]'
+    ),
+    "finishedAt" = NOW(),
+    "updatedAt" = NOW()
+WHERE id = $1
+`
+
+type FailImportJobParams struct {
+	ID     uuid.UUID       `json:"id"`
+	Errors json.RawMessage `json:"errors"`
+}
+
+// the tryout is rolled back on failure, so no module is left imported
+func (q *Queries) FailImportJob(ctx context.Context, arg FailImportJobParams) error {
+	_, err := q.db.ExecContext(ctx, failImportJob, arg.ID, arg.Errors)
+	return err
+}
+
+const getImportJob = `-- name: GetImportJob :one
+SELECT id, url, status, "totalModules", "importedModules", progress, "tryoutId", errors, result, "startedAt", "finishedAt", "updatedAt", "createdAt", "idempotencyKey", "fileName"
+FROM "importJobs"
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetImportJob(ctx context.Context, id uuid.UUID) (ImportJobs, error) {
+	row := q.db.QueryRowContext(ctx, getImportJob, id)
+	var i ImportJobs
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Status,
+		&i.TotalModules,
+		&i.ImportedModules,
+		&i.Progress,
+		&i.TryoutId,
+		&i.Errors,
+		&i.Result,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.IdempotencyKey,
+		&i.FileName,
+	)
+	return i, err
+}
+
+const getImportJobByIdempotencyKey = `-- name: GetImportJobByIdempotencyKey :one
+SELECT id, url, status, "totalModules", "importedModules", progress, "tryoutId", errors, result, "startedAt", "finishedAt", "updatedAt", "createdAt", "idempotencyKey", "fileName"
+FROM "importJobs"
+WHERE "idempotencyKey" = $1
+LIMIT 1
+`
+
+func (q *Queries) GetImportJobByIdempotencyKey(ctx context.Context, idempotencykey sql.NullString) (ImportJobs, error) {
+	row := q.db.QueryRowContext(ctx, getImportJobByIdempotencyKey, idempotencykey)
+	var i ImportJobs
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Status,
+		&i.TotalModules,
+		&i.ImportedModules,
+		&i.Progress,
+		&i.TryoutId,
+		&i.Errors,
+		&i.Result,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.IdempotencyKey,
+		&i.FileName,
+	)
+	return i, err
+}
+
+const releaseImportJobIdempotencyKey = `-- name: ReleaseImportJobIdempotencyKey :exec
+UPDATE "importJobs"
+SET "idempotencyKey" = NULL,
+    "updatedAt" = NOW()
+WHERE id = $1
+`
+
+// lets a request with the same key create a new job
+func (q *Queries) ReleaseImportJobIdempotencyKey(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, releaseImportJobIdempotencyKey, id)
+	return err
+}
+
+const retryImportJob = `-- name: RetryImportJob :exec
+UPDATE "importJobs"
+SET status = 'retrying',
+    errors = $2,
+    "importedModules" = 0,
+    progress = COALESCE(
+        (
+            SELECT jsonb_agg(jsonb_set(module, '{status}', '"pending"'))
+            FROM jsonb_array_elements(progress) AS module
+        ),
+        '[]'
+    ),
+    "updatedAt" = NOW()
+WHERE id = $1
+`
+
+type RetryImportJobParams struct {
+	ID     uuid.UUID       `json:"id"`
+	Errors json.RawMessage `json:"errors"`
+}
+
+// the tryout of the failed attempt is rolled back, so no module is left
+// imported until the next attempt
+func (q *Queries) RetryImportJob(ctx context.Context, arg RetryImportJobParams) error {
+	_, err := q.db.ExecContext(ctx, retryImportJob, arg.ID, arg.Errors)
+	return err
+}
+
+const startImportJob = `-- name: StartImportJob :exec
+UPDATE "importJobs"
+SET status = 'running',
+    "totalModules" = $2,
+    "importedModules" = 0,
+    progress = $3,
+    errors = '[]',
+    "startedAt" = NOW(),
+    "finishedAt" = NULL,
+    "updatedAt" = NOW()
+WHERE id = $1
+`
+
+type StartImportJobParams struct {
+	ID           uuid.UUID       `json:"id"`
+	TotalModules int32           `json:"totalModules"`
+	Progress     json.RawMessage `json:"progress"`
+}
+
+// a retried job starts over from the state left by the previous attempt
+func (q *Queries) StartImportJob(ctx context.Context, arg StartImportJobParams) error {
+	_, err := q.db.ExecContext(ctx, startImportJob, arg.ID, arg.TotalModules, arg.Progress)
+	return err
+}
+
+const updateImportJobProgress = `-- name: UpdateImportJobProgress :exec
+UPDATE "importJobs"
+SET "importedModules" = $2,
+    progress = $3,
+    "updatedAt" = NOW()
+WHERE id = $1
+`
+
+type UpdateImportJobProgressParams struct {
+	ID              uuid.UUID       `json:"id"`
+	ImportedModules int32           `json:"importedModules"`
+	Progress        json.RawMessage `json:"progress"`
+}
+
+func (q *Queries) UpdateImportJobProgress(ctx context.Context, arg UpdateImportJobProgressParams) error {
+	_, err := q.db.ExecContext(ctx, updateImportJobProgress, arg.ID, arg.ImportedModules, arg.Progress)
+	return err
+}
@@ -0,0 +1,54 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: import_job_file.sql
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createImportJobFile = `-- name: CreateImportJobFile :exec
+INSERT INTO "importJobFiles" (
+        "jobId",
+        content
+    )
+VALUES ($1, $2)
+`
+
+type CreateImportJobFileParams struct {
+	JobId   uuid.UUID `json:"jobId"`
+	Content []byte    `json:"content"`
+}
+
+func (q *Queries) CreateImportJobFile(ctx context.Context, arg CreateImportJobFileParams) error {
+	_, err := q.db.ExecContext(ctx, createImportJobFile, arg.JobId, arg.Content)
+	return err
+}
+
+const deleteImportJobFile = `-- name: DeleteImportJobFile :exec
+DELETE FROM "importJobFiles"
+WHERE "jobId" = $1
+`
+
+func (q *Queries) DeleteImportJobFile(ctx context.Context, jobid uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteImportJobFile, jobid)
+	return err
+}
+
+const getImportJobFile = `-- name: GetImportJobFile :one
+SELECT "jobId", content, "createdAt"
+FROM "importJobFiles"
+WHERE "jobId" = $1
+LIMIT 1
+`
+
+func (q *Queries) GetImportJobFile(ctx context.Context, jobid uuid.UUID) (ImportJobFiles, error) {
+	row := q.db.QueryRowContext(ctx, getImportJobFile, jobid)
+	var i ImportJobFiles
+	err := row.Scan(&i.JobId, &i.Content, &i.CreatedAt)
+	return i, err
+}
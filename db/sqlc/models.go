@@ -6,11 +6,21 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+type AcceptedAnswers struct {
+	ID          uuid.UUID     `json:"id"`
+	QuestionId  uuid.UUID     `json:"questionId"`
+	Content     string        `json:"content"`
+	AnswerOrder sql.NullInt32 `json:"answerOrder"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+	CreatedAt   time.Time     `json:"createdAt"`
+}
+
 type Answers struct {
 	OptionId         uuid.UUID `json:"optionId"`
 	QuestionId       uuid.UUID `json:"questionId"`
@@ -19,6 +29,30 @@ type Answers struct {
 	CreatedAt        time.Time `json:"createdAt"`
 }
 
+type ImportJobFiles struct {
+	JobId     uuid.UUID `json:"jobId"`
+	Content   []byte    `json:"content"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ImportJobs struct {
+	ID              uuid.UUID       `json:"id"`
+	Url             string          `json:"url"`
+	Status          string          `json:"status"`
+	TotalModules    int32           `json:"totalModules"`
+	ImportedModules int32           `json:"importedModules"`
+	Progress        json.RawMessage `json:"progress"`
+	TryoutId        uuid.NullUUID   `json:"tryoutId"`
+	Errors          json.RawMessage `json:"errors"`
+	Result          json.RawMessage `json:"result"`
+	StartedAt       sql.NullTime    `json:"startedAt"`
+	FinishedAt      sql.NullTime    `json:"finishedAt"`
+	UpdatedAt       time.Time       `json:"updatedAt"`
+	CreatedAt       time.Time       `json:"createdAt"`
+	IdempotencyKey  sql.NullString  `json:"idempotencyKey"`
+	FileName        string          `json:"fileName"`
+}
+
 type ModuleInstances struct {
 	ID               uuid.UUID `json:"id"`
 	ModuleId         uuid.UUID `json:"moduleId"`
@@ -29,31 +63,69 @@ type ModuleInstances struct {
 }
 
 type Modules struct {
-	ID          uuid.UUID     `json:"id"`
-	Title       string        `json:"title"`
-	TryoutId    uuid.UUID     `json:"tryoutId"`
-	ModuleOrder sql.NullInt32 `json:"moduleOrder"`
-	UpdatedAt   time.Time     `json:"updatedAt"`
-	CreatedAt   time.Time     `json:"createdAt"`
+	ID           uuid.UUID     `json:"id"`
+	Title        string        `json:"title"`
+	TryoutId     uuid.UUID     `json:"tryoutId"`
+	ModuleOrder  sql.NullInt32 `json:"moduleOrder"`
+	UpdatedAt    time.Time     `json:"updatedAt"`
+	CreatedAt    time.Time     `json:"createdAt"`
+	Duration     sql.NullInt32 `json:"duration"`
+	Instructions string        `json:"instructions"`
+	Description  string        `json:"description"`
 }
 
 type Options struct {
-	ID          uuid.UUID     `json:"id"`
-	QuestionId  uuid.UUID     `json:"questionId"`
-	Content     string        `json:"content"`
-	IsTrue      bool          `json:"isTrue"`
-	OptionOrder sql.NullInt32 `json:"optionOrder"`
-	UpdatedAt   time.Time     `json:"updatedAt"`
-	CreatedAt   time.Time     `json:"createdAt"`
+	ID          uuid.UUID       `json:"id"`
+	QuestionId  uuid.UUID       `json:"questionId"`
+	Content     string          `json:"content"`
+	IsTrue      bool            `json:"isTrue"`
+	OptionOrder sql.NullInt32   `json:"optionOrder"`
+	UpdatedAt   time.Time       `json:"updatedAt"`
+	CreatedAt   time.Time       `json:"createdAt"`
+	Explanation string          `json:"explanation"`
+	Points      sql.NullFloat64 `json:"points"`
+}
+
+type Passages struct {
+	ID           uuid.UUID     `json:"id"`
+	ModuleId     uuid.UUID     `json:"moduleId"`
+	Content      string        `json:"content"`
+	PassageOrder sql.NullInt32 `json:"passageOrder"`
+	UpdatedAt    time.Time     `json:"updatedAt"`
+	CreatedAt    time.Time     `json:"createdAt"`
+}
+
+type ProcessedMessages struct {
+	ProcessId string          `json:"processId"`
+	Status    string          `json:"status"`
+	Result    json.RawMessage `json:"result"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type QuestionTags struct {
+	QuestionId uuid.UUID     `json:"questionId"`
+	TagId      uuid.UUID     `json:"tagId"`
+	TagOrder   sql.NullInt32 `json:"tagOrder"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+	CreatedAt  time.Time     `json:"createdAt"`
 }
 
 type Questions struct {
-	ID            uuid.UUID     `json:"id"`
-	Content       string        `json:"content"`
-	ModuleId      uuid.UUID     `json:"moduleId"`
-	QuestionOrder sql.NullInt32 `json:"questionOrder"`
-	UpdatedAt     time.Time     `json:"updatedAt"`
-	CreatedAt     time.Time     `json:"createdAt"`
+	ID               uuid.UUID       `json:"id"`
+	Content          string          `json:"content"`
+	ModuleId         uuid.UUID       `json:"moduleId"`
+	QuestionOrder    sql.NullInt32   `json:"questionOrder"`
+	UpdatedAt        time.Time       `json:"updatedAt"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	Type             string          `json:"type"`
+	CaseSensitive    bool            `json:"caseSensitive"`
+	NumericAnswer    sql.NullFloat64 `json:"numericAnswer"`
+	NumericTolerance sql.NullFloat64 `json:"numericTolerance"`
+	Explanation      string          `json:"explanation"`
+	Points           float64         `json:"points"`
+	WrongPenalty     float64         `json:"wrongPenalty"`
+	BlankScore       float64         `json:"blankScore"`
+	PassageId        uuid.NullUUID   `json:"passageId"`
 }
 
 type Roles struct {
@@ -61,6 +133,14 @@ type Roles struct {
 	Type string    `json:"type"`
 }
 
+type Tags struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
 type Transactions struct {
 	ID        uuid.UUID `json:"id"`
 	TryoutId  uuid.UUID `json:"tryoutId"`
@@ -81,14 +161,16 @@ type TryoutInstances struct {
 }
 
 type Tryouts struct {
-	ID        uuid.UUID `json:"id"`
-	Title     string    `json:"title"`
-	Price     string    `json:"price"`
-	Status    string    `json:"status"`
-	StartedAt time.Time `json:"startedAt"`
-	EndedAt   time.Time `json:"endedAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
-	CreatedAt time.Time `json:"createdAt"`
+	ID          uuid.UUID     `json:"id"`
+	Title       string        `json:"title"`
+	Price       string        `json:"price"`
+	Status      string        `json:"status"`
+	StartedAt   time.Time     `json:"startedAt"`
+	EndedAt     time.Time     `json:"endedAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	Description string        `json:"description"`
+	Duration    sql.NullInt32 `json:"duration"`
 }
 
 type Users struct {
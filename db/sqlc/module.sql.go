@@ -16,20 +16,33 @@ const createModule = `-- name: CreateModule :one
 INSERT INTO "modules" (
         title,
         "tryoutId",
-        "moduleOrder"
+        "moduleOrder",
+        duration,
+        instructions,
+        description
     )
-VALUES ($1, $2, $3)
-RETURNING id, title, "tryoutId", "moduleOrder", "updatedAt", "createdAt"
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, title, "tryoutId", "moduleOrder", "updatedAt", "createdAt", duration, instructions, description
 `
 
 type CreateModuleParams struct {
-	Title       string        `json:"title"`
-	TryoutId    uuid.UUID     `json:"tryoutId"`
-	ModuleOrder sql.NullInt32 `json:"moduleOrder"`
+	Title        string        `json:"title"`
+	TryoutId     uuid.UUID     `json:"tryoutId"`
+	ModuleOrder  sql.NullInt32 `json:"moduleOrder"`
+	Duration     sql.NullInt32 `json:"duration"`
+	Instructions string        `json:"instructions"`
+	Description  string        `json:"description"`
 }
 
 func (q *Queries) CreateModule(ctx context.Context, arg CreateModuleParams) (Modules, error) {
-	row := q.db.QueryRowContext(ctx, createModule, arg.Title, arg.TryoutId, arg.ModuleOrder)
+	row := q.db.QueryRowContext(ctx, createModule,
+		arg.Title,
+		arg.TryoutId,
+		arg.ModuleOrder,
+		arg.Duration,
+		arg.Instructions,
+		arg.Description,
+	)
 	var i Modules
 	err := row.Scan(
 		&i.ID,
@@ -38,6 +51,49 @@ func (q *Queries) CreateModule(ctx context.Context, arg CreateModuleParams) (Mod
 		&i.ModuleOrder,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.Duration,
+		&i.Instructions,
+		&i.Description,
 	)
 	return i, err
 }
+
+const listModulesByTryout = `-- name: ListModulesByTryout :many
+SELECT id, title, "tryoutId", "moduleOrder", "updatedAt", "createdAt", duration, instructions, description
+FROM "modules"
+WHERE "tryoutId" = $1
+ORDER BY "moduleOrder", "createdAt"
+`
+
+func (q *Queries) ListModulesByTryout(ctx context.Context, tryoutid uuid.UUID) ([]Modules, error) {
+	rows, err := q.db.QueryContext(ctx, listModulesByTryout, tryoutid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Modules{}
+	for rows.Next() {
+		var i Modules
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.TryoutId,
+			&i.ModuleOrder,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+			&i.Duration,
+			&i.Instructions,
+			&i.Description,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
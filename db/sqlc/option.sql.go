@@ -17,17 +17,21 @@ INSERT INTO "options" (
         content,
         "questionId",
         "isTrue",
-        "optionOrder"
+        "optionOrder",
+        explanation,
+        points
     )
-VALUES ($1, $2, $3, $4)
-RETURNING id, "questionId", content, "isTrue", "optionOrder", "updatedAt", "createdAt"
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, "questionId", content, "isTrue", "optionOrder", "updatedAt", "createdAt", explanation, points
 `
 
 type CreateOptionParams struct {
-	Content     string        `json:"content"`
-	QuestionId  uuid.UUID     `json:"questionId"`
-	IsTrue      bool          `json:"isTrue"`
-	OptionOrder sql.NullInt32 `json:"optionOrder"`
+	Content     string          `json:"content"`
+	QuestionId  uuid.UUID       `json:"questionId"`
+	IsTrue      bool            `json:"isTrue"`
+	OptionOrder sql.NullInt32   `json:"optionOrder"`
+	Explanation string          `json:"explanation"`
+	Points      sql.NullFloat64 `json:"points"`
 }
 
 func (q *Queries) CreateOption(ctx context.Context, arg CreateOptionParams) (Options, error) {
@@ -36,6 +40,8 @@ func (q *Queries) CreateOption(ctx context.Context, arg CreateOptionParams) (Opt
 		arg.QuestionId,
 		arg.IsTrue,
 		arg.OptionOrder,
+		arg.Explanation,
+		arg.Points,
 	)
 	var i Options
 	err := row.Scan(
@@ -46,6 +52,48 @@ func (q *Queries) CreateOption(ctx context.Context, arg CreateOptionParams) (Opt
 		&i.OptionOrder,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.Explanation,
+		&i.Points,
 	)
 	return i, err
 }
+
+const listOptionsByQuestion = `-- name: ListOptionsByQuestion :many
+SELECT id, "questionId", content, "isTrue", "optionOrder", "updatedAt", "createdAt", explanation, points
+FROM "options"
+WHERE "questionId" = $1
+ORDER BY "optionOrder", "createdAt"
+`
+
+func (q *Queries) ListOptionsByQuestion(ctx context.Context, questionid uuid.UUID) ([]Options, error) {
+	rows, err := q.db.QueryContext(ctx, listOptionsByQuestion, questionid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Options{}
+	for rows.Next() {
+		var i Options
+		if err := rows.Scan(
+			&i.ID,
+			&i.QuestionId,
+			&i.Content,
+			&i.IsTrue,
+			&i.OptionOrder,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+			&i.Explanation,
+			&i.Points,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,80 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: passage.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createPassage = `-- name: CreatePassage :one
+INSERT INTO "passages" (
+        content,
+        "moduleId",
+        "passageOrder"
+    )
+VALUES ($1, $2, $3)
+RETURNING id, "moduleId", content, "passageOrder", "updatedAt", "createdAt"
+`
+
+type CreatePassageParams struct {
+	Content      string        `json:"content"`
+	ModuleId     uuid.UUID     `json:"moduleId"`
+	PassageOrder sql.NullInt32 `json:"passageOrder"`
+}
+
+func (q *Queries) CreatePassage(ctx context.Context, arg CreatePassageParams) (Passages, error) {
+	row := q.db.QueryRowContext(ctx, createPassage, arg.Content, arg.ModuleId, arg.PassageOrder)
+	var i Passages
+	err := row.Scan(
+		&i.ID,
+		&i.ModuleId,
+		&i.Content,
+		&i.PassageOrder,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listPassagesByModule = `-- name: ListPassagesByModule :many
+SELECT id, "moduleId", content, "passageOrder", "updatedAt", "createdAt"
+FROM "passages"
+WHERE "moduleId" = $1
+ORDER BY "passageOrder", "createdAt"
+`
+
+func (q *Queries) ListPassagesByModule(ctx context.Context, moduleid uuid.UUID) ([]Passages, error) {
+	rows, err := q.db.QueryContext(ctx, listPassagesByModule, moduleid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Passages{}
+	for rows.Next() {
+		var i Passages
+		if err := rows.Scan(
+			&i.ID,
+			&i.ModuleId,
+			&i.Content,
+			&i.PassageOrder,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
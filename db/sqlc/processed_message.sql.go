@@ -0,0 +1,51 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: processed_message.sql
+
+package db
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const createProcessedMessage = `-- name: CreateProcessedMessage :exec
+INSERT INTO "processedMessages" (
+        "processId",
+        status,
+        result
+    )
+VALUES ($1, $2, $3)
+ON CONFLICT ("processId") DO NOTHING
+`
+
+type CreateProcessedMessageParams struct {
+	ProcessId string          `json:"processId"`
+	Status    string          `json:"status"`
+	Result    json.RawMessage `json:"result"`
+}
+
+func (q *Queries) CreateProcessedMessage(ctx context.Context, arg CreateProcessedMessageParams) error {
+	_, err := q.db.ExecContext(ctx, createProcessedMessage, arg.ProcessId, arg.Status, arg.Result)
+	return err
+}
+
+const getProcessedMessage = `-- name: GetProcessedMessage :one
+SELECT "processId", status, result, "createdAt"
+FROM "processedMessages"
+WHERE "processId" = $1
+LIMIT 1
+`
+
+func (q *Queries) GetProcessedMessage(ctx context.Context, processid string) (ProcessedMessages, error) {
+	row := q.db.QueryRowContext(ctx, getProcessedMessage, processid)
+	var i ProcessedMessages
+	err := row.Scan(
+		&i.ProcessId,
+		&i.Status,
+		&i.Result,
+		&i.CreatedAt,
+	)
+	return i, err
+}
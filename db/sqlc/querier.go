@@ -6,13 +6,46 @@ package db
 
 import (
 	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
 )
 
 type Querier interface {
+	CompleteImportJob(ctx context.Context, arg CompleteImportJobParams) error
+	CreateAcceptedAnswer(ctx context.Context, arg CreateAcceptedAnswerParams) (AcceptedAnswers, error)
+	CreateImportJob(ctx context.Context, arg CreateImportJobParams) (ImportJobs, error)
+	CreateImportJobFile(ctx context.Context, arg CreateImportJobFileParams) error
 	CreateModule(ctx context.Context, arg CreateModuleParams) (Modules, error)
 	CreateOption(ctx context.Context, arg CreateOptionParams) (Options, error)
+	CreatePassage(ctx context.Context, arg CreatePassageParams) (Passages, error)
+	CreateProcessedMessage(ctx context.Context, arg CreateProcessedMessageParams) error
 	CreateQuestion(ctx context.Context, arg CreateQuestionParams) (Questions, error)
+	CreateQuestionTag(ctx context.Context, arg CreateQuestionTagParams) (QuestionTags, error)
 	CreateTryout(ctx context.Context, arg CreateTryoutParams) (Tryouts, error)
+	DeleteImportJobFile(ctx context.Context, jobid uuid.UUID) error
+	// the tryout is rolled back on failure, so no module is left imported
+	FailImportJob(ctx context.Context, arg FailImportJobParams) error
+	GetImportJob(ctx context.Context, id uuid.UUID) (ImportJobs, error)
+	GetImportJobByIdempotencyKey(ctx context.Context, idempotencykey sql.NullString) (ImportJobs, error)
+	GetImportJobFile(ctx context.Context, jobid uuid.UUID) (ImportJobFiles, error)
+	GetProcessedMessage(ctx context.Context, processid string) (ProcessedMessages, error)
+	GetTryout(ctx context.Context, id uuid.UUID) (Tryouts, error)
+	ListAcceptedAnswersByQuestion(ctx context.Context, questionid uuid.UUID) ([]AcceptedAnswers, error)
+	ListModulesByTryout(ctx context.Context, tryoutid uuid.UUID) ([]Modules, error)
+	ListOptionsByQuestion(ctx context.Context, questionid uuid.UUID) ([]Options, error)
+	ListPassagesByModule(ctx context.Context, moduleid uuid.UUID) ([]Passages, error)
+	ListQuestionsByModule(ctx context.Context, moduleid uuid.UUID) ([]Questions, error)
+	ListTagsByQuestion(ctx context.Context, questionid uuid.UUID) ([]Tags, error)
+	// lets a request with the same key create a new job
+	ReleaseImportJobIdempotencyKey(ctx context.Context, id uuid.UUID) error
+	// the tryout of the failed attempt is rolled back, so no module is left
+	// imported until the next attempt
+	RetryImportJob(ctx context.Context, arg RetryImportJobParams) error
+	// a retried job starts over from the state left by the previous attempt
+	StartImportJob(ctx context.Context, arg StartImportJobParams) error
+	UpdateImportJobProgress(ctx context.Context, arg UpdateImportJobProgressParams) error
+	UpsertTag(ctx context.Context, arg UpsertTagParams) (Tags, error)
 }
 
 var _ Querier = (*Queries)(nil)
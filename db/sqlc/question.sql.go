@@ -16,20 +16,51 @@ const createQuestion = `-- name: CreateQuestion :one
 INSERT INTO "questions" (
         content,
         "moduleId",
-        "questionOrder"
+        "questionOrder",
+        type,
+        "caseSensitive",
+        "numericAnswer",
+        "numericTolerance",
+        explanation,
+        points,
+        "wrongPenalty",
+        "blankScore",
+        "passageId"
     )
-VALUES ($1, $2, $3)
-RETURNING id, content, "moduleId", "questionOrder", "updatedAt", "createdAt"
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+RETURNING id, content, "moduleId", "questionOrder", "updatedAt", "createdAt", type, "caseSensitive", "numericAnswer", "numericTolerance", explanation, points, "wrongPenalty", "blankScore", "passageId"
 `
 
 type CreateQuestionParams struct {
-	Content       string        `json:"content"`
-	ModuleId      uuid.UUID     `json:"moduleId"`
-	QuestionOrder sql.NullInt32 `json:"questionOrder"`
+	Content          string          `json:"content"`
+	ModuleId         uuid.UUID       `json:"moduleId"`
+	QuestionOrder    sql.NullInt32   `json:"questionOrder"`
+	Type             string          `json:"type"`
+	CaseSensitive    bool            `json:"caseSensitive"`
+	NumericAnswer    sql.NullFloat64 `json:"numericAnswer"`
+	NumericTolerance sql.NullFloat64 `json:"numericTolerance"`
+	Explanation      string          `json:"explanation"`
+	Points           float64         `json:"points"`
+	WrongPenalty     float64         `json:"wrongPenalty"`
+	BlankScore       float64         `json:"blankScore"`
+	PassageId        uuid.NullUUID   `json:"passageId"`
 }
 
 func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams) (Questions, error) {
-	row := q.db.QueryRowContext(ctx, createQuestion, arg.Content, arg.ModuleId, arg.QuestionOrder)
+	row := q.db.QueryRowContext(ctx, createQuestion,
+		arg.Content,
+		arg.ModuleId,
+		arg.QuestionOrder,
+		arg.Type,
+		arg.CaseSensitive,
+		arg.NumericAnswer,
+		arg.NumericTolerance,
+		arg.Explanation,
+		arg.Points,
+		arg.WrongPenalty,
+		arg.BlankScore,
+		arg.PassageId,
+	)
 	var i Questions
 	err := row.Scan(
 		&i.ID,
@@ -38,6 +69,61 @@ func (q *Queries) CreateQuestion(ctx context.Context, arg CreateQuestionParams)
 		&i.QuestionOrder,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.Type,
+		&i.CaseSensitive,
+		&i.NumericAnswer,
+		&i.NumericTolerance,
+		&i.Explanation,
+		&i.Points,
+		&i.WrongPenalty,
+		&i.BlankScore,
+		&i.PassageId,
 	)
 	return i, err
 }
+
+const listQuestionsByModule = `-- name: ListQuestionsByModule :many
+SELECT id, content, "moduleId", "questionOrder", "updatedAt", "createdAt", type, "caseSensitive", "numericAnswer", "numericTolerance", explanation, points, "wrongPenalty", "blankScore", "passageId"
+FROM "questions"
+WHERE "moduleId" = $1
+ORDER BY "questionOrder", "createdAt"
+`
+
+func (q *Queries) ListQuestionsByModule(ctx context.Context, moduleid uuid.UUID) ([]Questions, error) {
+	rows, err := q.db.QueryContext(ctx, listQuestionsByModule, moduleid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Questions{}
+	for rows.Next() {
+		var i Questions
+		if err := rows.Scan(
+			&i.ID,
+			&i.Content,
+			&i.ModuleId,
+			&i.QuestionOrder,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+			&i.Type,
+			&i.CaseSensitive,
+			&i.NumericAnswer,
+			&i.NumericTolerance,
+			&i.Explanation,
+			&i.Points,
+			&i.WrongPenalty,
+			&i.BlankScore,
+			&i.PassageId,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
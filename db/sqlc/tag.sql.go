@@ -0,0 +1,107 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.26.0
+// source: tag.sql
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createQuestionTag = `-- name: CreateQuestionTag :one
+INSERT INTO "questionTags" (
+        "questionId",
+        "tagId",
+        "tagOrder"
+    )
+VALUES ($1, $2, $3)
+RETURNING "questionId", "tagId", "tagOrder", "updatedAt", "createdAt"
+`
+
+type CreateQuestionTagParams struct {
+	QuestionId uuid.UUID     `json:"questionId"`
+	TagId      uuid.UUID     `json:"tagId"`
+	TagOrder   sql.NullInt32 `json:"tagOrder"`
+}
+
+func (q *Queries) CreateQuestionTag(ctx context.Context, arg CreateQuestionTagParams) (QuestionTags, error) {
+	row := q.db.QueryRowContext(ctx, createQuestionTag, arg.QuestionId, arg.TagId, arg.TagOrder)
+	var i QuestionTags
+	err := row.Scan(
+		&i.QuestionId,
+		&i.TagId,
+		&i.TagOrder,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listTagsByQuestion = `-- name: ListTagsByQuestion :many
+SELECT tags.id, tags.type, tags.name, tags."updatedAt", tags."createdAt"
+FROM "tags"
+    JOIN "questionTags" ON "questionTags"."tagId" = "tags".id
+WHERE "questionTags"."questionId" = $1
+ORDER BY "questionTags"."tagOrder", "tags".type
+`
+
+func (q *Queries) ListTagsByQuestion(ctx context.Context, questionid uuid.UUID) ([]Tags, error) {
+	rows, err := q.db.QueryContext(ctx, listTagsByQuestion, questionid)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Tags{}
+	for rows.Next() {
+		var i Tags
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.Name,
+			&i.UpdatedAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertTag = `-- name: UpsertTag :one
+INSERT INTO "tags" (
+        type,
+        name
+    )
+VALUES ($1, $2)
+ON CONFLICT (type, name) DO UPDATE SET name = EXCLUDED.name
+RETURNING id, type, name, "updatedAt", "createdAt"
+`
+
+type UpsertTagParams struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+func (q *Queries) UpsertTag(ctx context.Context, arg UpsertTagParams) (Tags, error) {
+	row := q.db.QueryRowContext(ctx, upsertTag, arg.Type, arg.Name)
+	var i Tags
+	err := row.Scan(
+		&i.ID,
+		&i.Type,
+		&i.Name,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
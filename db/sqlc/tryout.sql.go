@@ -7,7 +7,10 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 const createTryout = `-- name: CreateTryout :one
@@ -16,18 +19,22 @@ INSERT INTO "tryouts" (
         price,
         status,
         "startedAt",
-        "endedAt"
+        "endedAt",
+        description,
+        duration
     )
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, title, price, status, "startedAt", "endedAt", "updatedAt", "createdAt"
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, title, price, status, "startedAt", "endedAt", "updatedAt", "createdAt", description, duration
 `
 
 type CreateTryoutParams struct {
-	Title     string    `json:"title"`
-	Price     string    `json:"price"`
-	Status    string    `json:"status"`
-	StartedAt time.Time `json:"startedAt"`
-	EndedAt   time.Time `json:"endedAt"`
+	Title       string        `json:"title"`
+	Price       string        `json:"price"`
+	Status      string        `json:"status"`
+	StartedAt   time.Time     `json:"startedAt"`
+	EndedAt     time.Time     `json:"endedAt"`
+	Description string        `json:"description"`
+	Duration    sql.NullInt32 `json:"duration"`
 }
 
 func (q *Queries) CreateTryout(ctx context.Context, arg CreateTryoutParams) (Tryouts, error) {
@@ -37,6 +44,8 @@ func (q *Queries) CreateTryout(ctx context.Context, arg CreateTryoutParams) (Try
 		arg.Status,
 		arg.StartedAt,
 		arg.EndedAt,
+		arg.Description,
+		arg.Duration,
 	)
 	var i Tryouts
 	err := row.Scan(
@@ -48,6 +57,33 @@ func (q *Queries) CreateTryout(ctx context.Context, arg CreateTryoutParams) (Try
 		&i.EndedAt,
 		&i.UpdatedAt,
 		&i.CreatedAt,
+		&i.Description,
+		&i.Duration,
+	)
+	return i, err
+}
+
+const getTryout = `-- name: GetTryout :one
+SELECT id, title, price, status, "startedAt", "endedAt", "updatedAt", "createdAt", description, duration
+FROM "tryouts"
+WHERE id = $1
+LIMIT 1
+`
+
+func (q *Queries) GetTryout(ctx context.Context, id uuid.UUID) (Tryouts, error) {
+	row := q.db.QueryRowContext(ctx, getTryout, id)
+	var i Tryouts
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.Price,
+		&i.Status,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.UpdatedAt,
+		&i.CreatedAt,
+		&i.Description,
+		&i.Duration,
 	)
 	return i, err
 }
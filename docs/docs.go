@@ -15,6 +15,58 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/api/parsing-sheets/jobs/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reports the status, per-module progress, resulting tryout and parse errors of an import job",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Parser Sheets"
+                ],
+                "summary": "Get the status of an import job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/api/parsing-sheets/parse": {
             "post": {
                 "security": [
@@ -22,7 +74,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new tryout by parsing google sheet with the provided parameters",
+                "description": "Queues an import job that creates a new tryout by parsing google sheet with the provided parameters. Parameters left empty are read from the README sheet",
                 "consumes": [
                     "application/json"
                 ],
@@ -42,13 +94,373 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/api.ParsingSheetsParamRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repeating a request with the same key returns the job created by the first one",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/parsing-sheets/template": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Produces a workbook with a README documenting the rules, a module sheet with the header and dropdowns on the answer, key, type and case sensitivity columns, and protected header rows. With format google a new google sheet is created instead",
+                "produces": [
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/json"
+                ],
+                "tags": [
+                    "Parser Sheets"
+                ],
+                "summary": "Get a spreadsheet template to fill in",
+                "parameters": [
+                    {
+                        "enum": [
+                            "xlsx",
+                            "google"
+                        ],
+                        "type": "string",
+                        "description": "xlsx (default) or google",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account to share the google sheet with, required with format google",
+                        "name": "email",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.ExportGoogleSheetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/parsing-sheets/tryouts/{id}/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Writes a tryout with its modules, questions and options in the layout accepted by the import, so it can be edited and imported again",
+                "produces": [
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Parser Sheets"
+                ],
+                "summary": "Export a tryout to an .xlsx workbook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tryout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Success",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/parsing-sheets/tryouts/{id}/export/google": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a google sheet holding a tryout in the layout accepted by the import and shares it with the given email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Parser Sheets"
+                ],
+                "summary": "Export a tryout to a new google sheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tryout ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Account to share the new google sheet with",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ExportGoogleSheetRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/api.ExportGoogleSheetResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/parsing-sheets/upload": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues an import job that creates a new tryout from an .xlsx or .ods workbook, a zip of CSV files named after their modules or a single CSV file, with the same rules as a google sheet. Parameters left empty are read from the README sheet",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Parser Sheets"
+                ],
+                "summary": "Create a new tryout from an uploaded workbook",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "The .xlsx, .ods, .zip or .csv file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tryout title",
+                        "name": "title",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tryout price",
+                        "name": "price",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tryout status",
+                        "name": "status",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the tryout in RFC 3339",
+                        "name": "startedAt",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the tryout in RFC 3339",
+                        "name": "endedAt",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Tryout description",
+                        "name": "description",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Tryout duration in minutes",
+                        "name": "duration",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Titles or gids of the sheets to import",
+                        "name": "sheets",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Repeating a request with the same key returns the job created by the first one",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/api.ImportJobResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/parsing-sheets/validate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Walks every sheet and reports all errors and warnings with their A1 cell reference, without writing anything",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Parser Sheets"
+                ],
+                "summary": "Validate a google sheet without importing it",
+                "parameters": [
+                    {
+                        "description": "Request body to validate a google sheet",
+                        "name": "requestBody",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.ValidateSheetsParamRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "Success",
                         "schema": {
-                            "$ref": "#/definitions/api.ParsingSheetsParamResponse"
+                            "$ref": "#/definitions/api.ValidateSheetsParamResponse"
                         }
                     },
                     "400": {
@@ -68,6 +480,29 @@ const docTemplate = `{
         }
     },
     "definitions": {
+        "api.AcceptedAnswerResponse": {
+            "type": "object",
+            "properties": {
+                "answerOrder": {
+                    "type": "integer"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "questionId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
         "api.ErrorResponse": {
             "type": "object",
             "properties": {
@@ -76,18 +511,123 @@ const docTemplate = `{
                 }
             }
         },
+        "api.ExportGoogleSheetRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email is given write access to the new spreadsheet, which is otherwise\nonly reachable by the service account that creates it",
+                    "type": "string"
+                }
+            }
+        },
+        "api.ExportGoogleSheetResponse": {
+            "type": "object",
+            "properties": {
+                "spreadsheetId": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ImportJobResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/parser.Issue"
+                    }
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "importedModules": {
+                    "type": "integer"
+                },
+                "progress": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.ModuleProgress"
+                    }
+                },
+                "result": {
+                    "$ref": "#/definitions/api.ParsingSheetsParamResponse"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "totalModules": {
+                    "type": "integer"
+                },
+                "tryoutId": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ModuleProgress": {
+            "type": "object",
+            "properties": {
+                "questions": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
         "api.ModuleResponse": {
             "type": "object",
             "properties": {
                 "createdAt": {
                     "type": "string"
                 },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "string"
                 },
+                "instructions": {
+                    "type": "string"
+                },
                 "moduleOrder": {
                     "type": "integer"
                 },
+                "passages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.PassageResponse"
+                    }
+                },
                 "questions": {
                     "type": "array",
                     "items": {
@@ -114,6 +654,9 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "explanation": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -123,6 +666,9 @@ const docTemplate = `{
                 "optionOrder": {
                     "type": "integer"
                 },
+                "points": {
+                    "type": "number"
+                },
                 "questionId": {
                     "type": "string"
                 },
@@ -134,12 +680,26 @@ const docTemplate = `{
         "api.ParsingSheetsParamRequest": {
             "type": "object",
             "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer",
+                    "minimum": 0
+                },
                 "endedAt": {
                     "type": "string"
                 },
                 "price": {
                     "type": "string"
                 },
+                "sheets": {
+                    "description": "Sheets lists the titles or gids of the sheets to import, all by default",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "startedAt": {
                     "type": "string"
                 },
@@ -160,6 +720,12 @@ const docTemplate = `{
                 "createdAt": {
                     "type": "string"
                 },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
                 "endedAt": {
                     "type": "string"
                 },
@@ -189,32 +755,169 @@ const docTemplate = `{
                 }
             }
         },
+        "api.PassageResponse": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "moduleId": {
+                    "type": "string"
+                },
+                "passageOrder": {
+                    "type": "integer"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
         "api.QuestionResponse": {
             "type": "object",
             "properties": {
+                "acceptedAnswers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.AcceptedAnswerResponse"
+                    }
+                },
+                "blankScore": {
+                    "type": "number"
+                },
+                "caseSensitive": {
+                    "type": "boolean"
+                },
                 "content": {
                     "type": "string"
                 },
                 "createdAt": {
                     "type": "string"
                 },
+                "explanation": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
                 "moduleId": {
                     "type": "string"
                 },
+                "numericAnswer": {
+                    "type": "number"
+                },
+                "numericTolerance": {
+                    "type": "number"
+                },
                 "options": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/api.OptionResponse"
                     }
                 },
+                "passageId": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "number"
+                },
                 "questionOrder": {
                     "type": "integer"
                 },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.TagResponse"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
                 "updatedAt": {
                     "type": "string"
+                },
+                "wrongPenalty": {
+                    "type": "number"
+                }
+            }
+        },
+        "api.TagResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ValidateSheetsParamRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "sheets": {
+                    "description": "Sheets lists the titles or gids of the sheets to import, all by default",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.ValidateSheetsParamResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "integer"
+                },
+                "issues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/parser.Issue"
+                    }
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "warnings": {
+                    "type": "integer"
+                }
+            }
+        },
+        "parser.Issue": {
+            "type": "object",
+            "properties": {
+                "cell": {
+                    "type": "string"
+                },
+                "column": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                },
+                "severity": {
+                    "type": "string"
+                },
+                "sheet": {
+                    "type": "string"
                 }
             }
         }
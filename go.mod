@@ -3,10 +3,19 @@ module github.com/online-tryout/parsing-sheets-api
 go 1.22.2
 
 require (
+	github.com/xuri/excelize/v2 v2.8.1
 	golang.org/x/oauth2 v0.15.0
 	google.golang.org/api v0.153.0
 )
 
+require (
+	github.com/mohae/deepcopy v0.0.0-20170929034955-c48cc78d4826 // indirect
+	github.com/richardlehane/mscfb v1.0.4 // indirect
+	github.com/richardlehane/msoleps v1.0.3 // indirect
+	github.com/xuri/efp v0.0.0-20231025114914-d1ff6096ae53 // indirect
+	github.com/xuri/nfp v0.0.0-20230919160717-d98342af3f05 // indirect
+)
+
 require (
 	cloud.google.com/go/compute/metadata v0.2.3 // indirect
 	github.com/KyleBanks/depth v1.2.1 // indirect
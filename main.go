@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
+	"database/sql"
 	"log"
+	"time"
 
 	_ "github.com/lib/pq"
 	"github.com/online-tryout/parsing-sheets-api/api"
 	"github.com/online-tryout/parsing-sheets-api/broker"
+	db "github.com/online-tryout/parsing-sheets-api/db/sqlc"
 	"github.com/online-tryout/parsing-sheets-api/util"
 )
 
@@ -22,29 +26,47 @@ func main() {
 		log.Fatal("can't load config: ", err)
 	}
 
+	// database
+	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	if err != nil {
+		log.Fatal("can't open database: ", err)
+	}
+	conn.SetMaxOpenConns(config.DBMaxOpenConns)
+	conn.SetMaxIdleConns(config.DBMaxIdleConns)
+	conn.SetConnMaxLifetime(config.DBConnMaxLifetime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	err = conn.PingContext(ctx)
+	cancel()
+	if err != nil {
+		log.Fatal("can't connect to database: ", err)
+	}
+
+	store := db.NewStore(conn)
+
 	// rabbitmq
-	rabbitmq, err := broker.NewRabbitMq(config.RabbitSource, &config)
+	rabbitmq, err := broker.NewRabbitMq(config.RabbitSource, &config, store)
 	if err != nil {
 		log.Fatal("can't connect to rabbitmq: ", err)
 	}
 
+	// server
+	server, err := api.NewServer(&config, store, rabbitmq)
+	if err != nil {
+		log.Fatal("can't create server: ", err)
+	}
+
 	// Create a channel to signal when the server is ready to shutdown
 	shutdown := make(chan struct{})
 
 	// Start consuming messages in a separate goroutine
 	go func() {
-		err := rabbitmq.ConsumeEvent("parsing-sheets-queue")
+		err := rabbitmq.ConsumeEvent(broker.ParsingSheetsQueue)
 		if err != nil {
 			log.Fatalf("Failed to consume messages: %v", err)
 		}
 	}()
 
-	// server
-	server, err := api.NewServer(&config, rabbitmq)
-	if err != nil {
-		log.Fatal("can't create server: ", err)
-	}
-
 	// Start server
 	go func() {
 		err := server.Start(config.ServerAddress)
@@ -0,0 +1,276 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+// Format lays a tryout out as sheets in the layout Parse reads, so a tryout
+// can be exported, edited and imported again without losing anything. Every
+// column is written with its first header name and every question states its
+// type and scores explicitly.
+func Format(tryout *Tryout) []util.SheetData {
+	sheets := []util.SheetData{formatReadme(tryout.Metadata)}
+	titles := sheetTitles(tryout.Modules)
+	for i, module := range tryout.Modules {
+		sheets = append(sheets, formatModule(module, titles[i], false))
+	}
+
+	for i := range sheets {
+		sheets[i].SheetId = int64(i)
+		sheets[i].Index = int64(i)
+	}
+	return sheets
+}
+
+func formatReadme(meta Metadata) util.SheetData {
+	values := [][]interface{}{}
+	add := func(key metadataKey, value string) {
+		if len(value) > 0 {
+			values = append(values, []interface{}{metadataKeyNames[key][0], value})
+		}
+	}
+
+	add(keyTitle, meta.Title)
+	add(keyPrice, meta.Price)
+	add(keyStatus, meta.Status)
+	add(keyStartedAt, meta.StartedAt)
+	add(keyEndedAt, meta.EndedAt)
+	add(keyDescription, meta.Description)
+	if meta.Duration > 0 {
+		add(keyDuration, strconv.Itoa(int(meta.Duration)))
+	}
+
+	return util.SheetData{Title: readmeSheet, Values: values}
+}
+
+// maxSheetTitle is the longest sheet name Excel accepts.
+const maxSheetTitle = 31
+
+// sheetTitleReplacer replaces the characters Excel doesn't accept in a sheet
+// name.
+var sheetTitleReplacer = strings.NewReplacer(":", "-", `\`, "-", "/", "-", "?", "-", "*", "-", "[", "(", "]", ")")
+
+// sheetTitles names the sheet of every module after its title, changed where
+// Excel wouldn't accept it: forbidden characters are replaced, long titles
+// are cut and duplicates are numbered, ignoring case as Excel does. Modules
+// whose sheet isn't named after their title keep it in a settings row.
+func sheetTitles(modules []Module) []string {
+	used := map[string]bool{strings.ToLower(readmeSheet): true}
+	titles := make([]string, len(modules))
+	for i, module := range modules {
+		base := strings.TrimSpace(sheetTitleReplacer.Replace(module.Title))
+		if len(base) == 0 {
+			base = fmt.Sprintf("Modul %d", i+1)
+		}
+
+		title := truncateSheetTitle(base, maxSheetTitle)
+		for n := 2; used[strings.ToLower(title)]; n++ {
+			suffix := fmt.Sprintf(" (%d)", n)
+			title = truncateSheetTitle(base, maxSheetTitle-len(suffix)) + suffix
+		}
+		used[strings.ToLower(title)] = true
+		titles[i] = title
+	}
+	return titles
+}
+
+// truncateSheetTitle cuts title to at most max characters. Excel doesn't
+// accept a name that starts or ends with an apostrophe either.
+func truncateSheetTitle(title string, max int) string {
+	if runes := []rune(title); len(runes) > max {
+		title = string(runes[:max])
+	}
+	return strings.Trim(title, "' ")
+}
+
+// formatModule writes the settings rows, the header and the questions of a
+// module on a sheet named sheetTitle, recording the title of the module when
+// the sheet name differs. Settings without a value are only written, left
+// blank to fill in, when emptySettings is set.
+func formatModule(module Module, sheetTitle string, emptySettings bool) util.SheetData {
+	values := [][]interface{}{}
+	add := func(key settingKey, value string) {
+		if len(value) > 0 || emptySettings {
+			values = append(values, []interface{}{settingKeyNames[key][0], value})
+		}
+	}
+
+	title := ""
+	if sheetTitle != module.Title {
+		title = module.Title
+	}
+	add(settingTitle, title)
+
+	duration := ""
+	if module.Duration > 0 {
+		duration = strconv.Itoa(int(module.Duration))
+	}
+	add(settingDuration, duration)
+	add(settingInstructions, module.Instructions)
+	add(settingDescription, module.Description)
+	if len(values) > 0 {
+		values = append(values, []interface{}{})
+	}
+
+	headerRow := len(values)
+	header := make([]interface{}, fieldCount)
+	for f := field(0); f < fieldCount; f++ {
+		header[f] = f.String()
+	}
+	values = append(values, header)
+
+	passages := map[int32]string{}
+	for _, passage := range module.Passages {
+		passages[passage.PassageOrder] = passage.Content
+	}
+
+	for i, question := range module.Questions {
+		if startsPassage(module.Questions, i) {
+			values = append(values, formatPassage(passages[*question.PassageOrder], countPassageQuestions(module.Questions, i)))
+		}
+		values = append(values, formatQuestion(question)...)
+	}
+
+	return util.SheetData{
+		Title:         sheetTitle,
+		Values:        values,
+		Validations:   columnValidations(headerRow + 1),
+		ProtectedRows: []int{headerRow},
+	}
+}
+
+// columnValidations offers the accepted values of the columns that take a
+// fixed list. Answers are only suggested since multiple-choice answers list
+// several letters and open questions hold text or numbers.
+func columnValidations(fromRow int) []util.Validation {
+	return []util.Validation{
+		{Column: int(fieldAnswer), FromRow: fromRow, Values: []string{"A", "B", "C", "D", "E"}},
+		{Column: int(fieldKey), FromRow: fromRow, Values: []string{"benar", "salah"}, Strict: true},
+		{Column: int(fieldType), FromRow: fromRow, Values: []string{
+			QuestionTypeSingle, QuestionTypeMultiple, QuestionTypeComplex, QuestionTypeShort, QuestionTypeNumeric,
+		}, Strict: true},
+		{Column: int(fieldCaseSensitive), FromRow: fromRow, Values: []string{"ya", "tidak"}, Strict: true},
+		{Column: int(fieldDifficulty), FromRow: fromRow, Values: []string{DifficultyEasy, DifficultyMedium, DifficultyHard}, Strict: true},
+	}
+}
+
+// startsPassage reports whether the i-th question is the first one of a
+// passage, which is written on its own row above it.
+func startsPassage(questions []Question, i int) bool {
+	order := questions[i].PassageOrder
+	if order == nil {
+		return false
+	}
+	prev := (*int32)(nil)
+	if i > 0 {
+		prev = questions[i-1].PassageOrder
+	}
+	return prev == nil || *prev != *order
+}
+
+func countPassageQuestions(questions []Question, from int) int {
+	count := 0
+	for _, question := range questions[from:] {
+		if question.PassageOrder == nil || *question.PassageOrder != *questions[from].PassageOrder {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+func formatPassage(content string, questions int) []interface{} {
+	row := make([]interface{}, fieldCount)
+	for i := range row {
+		row[i] = ""
+	}
+	row[fieldPassage] = content
+	row[fieldPassageQuestions] = strconv.Itoa(questions)
+	return row
+}
+
+// formatQuestion writes the question row, which holds the first option, and
+// a row for every other option.
+func formatQuestion(question Question) [][]interface{} {
+	row := make([]string, fieldCount)
+	row[fieldNumber] = strconv.Itoa(int(question.QuestionOrder))
+	row[fieldQuestion] = question.Content
+	row[fieldType] = question.Type
+	row[fieldExplanation] = question.Explanation
+	row[fieldPoints] = formatNumber(question.Points)
+	row[fieldWrongPenalty] = formatNumber(question.WrongPenalty)
+	row[fieldBlankScore] = formatNumber(question.BlankScore)
+	for _, tag := range question.Tags {
+		row[tagTypeFields[tag.Type]] = tag.Name
+	}
+
+	switch question.Type {
+	case QuestionTypeShort:
+		row[fieldAnswer] = strings.Join(question.AcceptedAnswers, "|")
+		row[fieldCaseSensitive] = formatBool(question.CaseSensitive)
+	case QuestionTypeNumeric:
+		if question.NumericAnswer != nil {
+			row[fieldAnswer] = formatNumber(*question.NumericAnswer)
+		}
+		if question.NumericTolerance != nil {
+			row[fieldTolerance] = formatNumber(*question.NumericTolerance)
+		}
+	case QuestionTypeComplex:
+	default:
+		answers := []string{}
+		for i, option := range question.Options {
+			if option.IsTrue {
+				answers = append(answers, string(rune('A'+i)))
+			}
+		}
+		row[fieldAnswer] = strings.Join(answers, ", ")
+	}
+
+	rows := [][]string{row}
+	for i, option := range question.Options {
+		if i > 0 {
+			rows = append(rows, make([]string, fieldCount))
+		}
+
+		optionRow := rows[len(rows)-1]
+		optionRow[fieldOption] = option.Content
+		optionRow[fieldOptionExplanation] = option.Explanation
+		if option.Points != nil {
+			optionRow[fieldOptionPoints] = formatNumber(*option.Points)
+		}
+		if question.Type == QuestionTypeComplex {
+			optionRow[fieldKey] = formatStatementKey(option.IsTrue)
+		}
+	}
+
+	values := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		values[i] = make([]interface{}, len(row))
+		for j, cell := range row {
+			values[i][j] = cell
+		}
+	}
+	return values
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatBool(value bool) string {
+	if value {
+		return "ya"
+	}
+	return "tidak"
+}
+
+func formatStatementKey(value bool) string {
+	if value {
+		return "benar"
+	}
+	return "salah"
+}
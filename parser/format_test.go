@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormatRoundTrip(t *testing.T) {
+	points := 3.0
+	example := exampleModule("Contoh")
+	example.ModuleOrder = 2
+
+	tryout := &Tryout{
+		Metadata: Metadata{
+			Title:       "Tryout UTBK",
+			Price:       "50000",
+			Status:      "published",
+			StartedAt:   "2026-01-02T08:00:00+07:00",
+			EndedAt:     "2026-01-03T08:00:00+07:00",
+			Description: "Tryout pertama",
+			Duration:    120,
+		},
+		Modules: []Module{
+			{
+				Title:       "Penalaran",
+				ModuleOrder: 1,
+				Passages:    []Passage{},
+				Questions: []Question{
+					{
+						Content: "Pilih jawaban benar", QuestionOrder: 1, Type: QuestionTypeSingle,
+						Points: 1, Tags: []Tag{},
+						Options: []Option{
+							{Content: "0", OptionOrder: 1},
+							{Content: "0", IsTrue: true, OptionOrder: 2, Explanation: "benar", Points: &points},
+						},
+						AcceptedAnswers: []string{},
+					},
+				},
+			},
+			example,
+		},
+	}
+	// titles that can't be sheet names are kept in a settings row
+	for _, order := range []int32{3, 4} {
+		module := tryout.Modules[0]
+		module.Title = "Penalaran Umum: Bacaan [Bagian 1] dan Kuantitatif"
+		module.ModuleOrder = order
+		tryout.Modules = append(tryout.Modules, module)
+	}
+
+	got, err := Parse(Format(tryout))
+	if err != nil {
+		t.Fatalf("Parse(Format()) returned error: %v", err)
+	}
+
+	normalize(tryout)
+	normalize(got)
+	if !reflect.DeepEqual(got, tryout) {
+		t.Errorf("round trip changed the tryout\ngot:  %+v\nwant: %+v", got, tryout)
+	}
+}
+
+func TestSheetTitles(t *testing.T) {
+	modules := []Module{
+		{Title: "Penalaran"},
+		{Title: "penalaran"},
+		{Title: "Matematika: Aljabar / Geometri [1]?"},
+		{Title: "Literasi dalam Bahasa Indonesia dan Bahasa Inggris"},
+		{Title: "Literasi dalam Bahasa Indonesia dan Bahasa Jepang"},
+		{Title: "readme"},
+		{Title: " "},
+	}
+	want := []string{
+		"Penalaran",
+		"penalaran (2)",
+		"Matematika- Aljabar - Geometri",
+		"Literasi dalam Bahasa Indonesia",
+		"Literasi dalam Bahasa Indon (2)",
+		"readme (2)",
+		"Modul 7",
+	}
+
+	got := sheetTitles(modules)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sheetTitles() = %q, want %q", got, want)
+	}
+}
+
+// normalize fills in what Parse always sets but a hand-written tryout may
+// leave out, so the two can be compared.
+func normalize(tryout *Tryout) {
+	for i := range tryout.Modules {
+		module := &tryout.Modules[i]
+		if module.Passages == nil {
+			module.Passages = []Passage{}
+		}
+		for j := range module.Questions {
+			question := &module.Questions[j]
+			if question.Options == nil {
+				question.Options = []Option{}
+			}
+			if question.AcceptedAnswers == nil {
+				question.AcceptedAnswers = []string{}
+			}
+			if question.Tags == nil {
+				question.Tags = []Tag{}
+			}
+			for k := range question.Options {
+				question.Options[k].OptionOrder = int32(k + 1)
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+// field identifies a column of a module sheet. Columns are located by their
+// header so authors can reorder them and keep extra columns of their own.
+type field int
+
+const (
+	fieldNumber field = iota
+	fieldQuestion
+	fieldAnswer
+	fieldOption
+	fieldKey
+	fieldType
+	fieldTolerance
+	fieldCaseSensitive
+	fieldExplanation
+	fieldOptionExplanation
+	fieldPoints
+	fieldWrongPenalty
+	fieldBlankScore
+	fieldOptionPoints
+	fieldPassage
+	fieldPassageQuestions
+	fieldTopic
+	fieldSubtopic
+	fieldDifficulty
+	fieldLearningObjective
+	fieldCount
+)
+
+// fieldHeaders lists the accepted headers of every field; the first one is
+// the name used in messages and templates. Headers are matched ignoring case
+// and punctuation, so "No." matches "no".
+var fieldHeaders = [fieldCount][]string{
+	fieldNumber:            {"No", "Nomor", "Number", "Nomor Soal"},
+	fieldQuestion:          {"Soal", "Pertanyaan", "Question"},
+	fieldAnswer:            {"Kunci", "Kunci Jawaban", "Jawaban", "Answer", "Answer Key"},
+	fieldOption:            {"Pilihan", "Pilihan Jawaban", "Opsi", "Option", "Options"},
+	fieldKey:               {"Kunci Pernyataan", "Benar Salah", "Statement Key"},
+	fieldType:              {"Tipe", "Tipe Soal", "Jenis Soal", "Type", "Question Type"},
+	fieldTolerance:         {"Toleransi", "Tolerance"},
+	fieldCaseSensitive:     {"Peka Huruf", "Case Sensitive"},
+	fieldExplanation:       {"Pembahasan", "Penjelasan", "Explanation"},
+	fieldOptionExplanation: {"Pembahasan Pilihan", "Penjelasan Pilihan", "Option Explanation"},
+	fieldPoints:            {"Bobot", "Poin", "Nilai Benar", "Points"},
+	fieldWrongPenalty:      {"Penalti", "Nilai Salah", "Penalty"},
+	fieldBlankScore:        {"Nilai Kosong", "Blank Score"},
+	fieldOptionPoints:      {"Poin Pilihan", "Bobot Pilihan", "Option Points"},
+	fieldPassage:           {"Bacaan", "Teks Bacaan", "Stimulus", "Passage"},
+	fieldPassageQuestions:  {"Jumlah Soal Bacaan", "Jumlah Soal", "Passage Questions"},
+	fieldTopic:             {"Topik", "Materi", "Topic"},
+	fieldSubtopic:          {"Subtopik", "Sub Topik", "Submateri", "Sub Materi", "Subtopic"},
+	fieldDifficulty:        {"Tingkat Kesulitan", "Kesulitan", "Level", "Difficulty"},
+	fieldLearningObjective: {"Tujuan Pembelajaran", "Kompetensi", "Indikator", "Learning Objective", "Competency"},
+}
+
+var requiredFields = []field{fieldNumber, fieldQuestion, fieldAnswer, fieldOption}
+
+var headerFields = func() map[string]field {
+	m := map[string]field{}
+	for f, headers := range fieldHeaders {
+		for _, header := range headers {
+			m[normalizeHeader(header)] = field(f)
+		}
+	}
+	return m
+}()
+
+func (f field) String() string {
+	return fieldHeaders[f][0]
+}
+
+// columns maps every field to its zero-based column index, or -1 when the
+// sheet has no column for it.
+type columns [fieldCount]int
+
+func (c columns) index(f field) int {
+	return c[f]
+}
+
+// readHeader maps the columns of the header row found by settings and
+// reports duplicated and missing headers; unknown headers are ignored. It
+// returns false when the sheet can't be read because required columns are
+// missing.
+func (p *sheetParser) readHeader(values [][]interface{}) bool {
+	if p.header >= len(values) {
+		p.reportCell(SeverityError, -1, 0, "no data found in sheet")
+		return false
+	}
+	header := values[p.header]
+
+	var found columns
+	for f := range found {
+		found[f] = -1
+	}
+
+	for col := range header {
+		name := cellString(header, col)
+		if len(name) == 0 {
+			continue
+		}
+
+		f, ok := headerFields[normalizeHeader(name)]
+		if !ok {
+			// extra columns are kept for the author's own notes
+			continue
+		}
+		if found[f] >= 0 {
+			p.reportCell(SeverityError, p.header, col, "column %q is already defined in column %s",
+				name, util.NumberToColumnLetter(int64(found[f])+1))
+			continue
+		}
+		found[f] = col
+	}
+
+	missing := []string{}
+	for _, f := range requiredFields {
+		if found[f] < 0 {
+			missing = append(missing, f.String())
+		}
+	}
+	if len(missing) > 0 {
+		p.reportCell(SeverityError, p.header, -1, "missing required columns: %s", strings.Join(missing, ", "))
+		return false
+	}
+
+	p.columns = found
+	return true
+}
+
+// normalizeHeader lowercases a header and replaces punctuation with single
+// spaces.
+func normalizeHeader(header string) string {
+	words := strings.FieldsFunc(strings.ToLower(header), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
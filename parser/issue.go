@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+type Issue struct {
+	Severity string `json:"severity"`
+	Sheet    string `json:"sheet"`
+	Row      int    `json:"row"`
+	Column   string `json:"column"`
+	Cell     string `json:"cell"`
+	Message  string `json:"message"`
+}
+
+func (issue Issue) String() string {
+	if len(issue.Sheet) == 0 {
+		return issue.Message
+	}
+	if len(issue.Cell) == 0 {
+		return fmt.Sprintf("sheet %s: %s", issue.Sheet, issue.Message)
+	}
+	return fmt.Sprintf("%s: %s", issue.Cell, issue.Message)
+}
+
+// ValidationError is returned by Parse when a spreadsheet has at least one
+// error-level issue. It carries every issue found, warnings included.
+type ValidationError struct {
+	Issues []Issue
+}
+
+func (e *ValidationError) Error() string {
+	errs := e.Errors()
+	if len(errs) == 0 {
+		return "spreadsheet is invalid"
+	}
+
+	msg := errs[0].String()
+	if len(errs) > 1 {
+		msg += fmt.Sprintf(" (and %d more errors)", len(errs)-1)
+	}
+	return msg
+}
+
+func (e *ValidationError) Errors() []Issue {
+	var errs []Issue
+	for _, issue := range e.Issues {
+		if issue.Severity == SeverityError {
+			errs = append(errs, issue)
+		}
+	}
+	return errs
+}
+
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// cellRef builds an A1 reference such as 'Module 1'!C5 from a zero-based
+// row and column index. A negative column, used when the sheet has no such
+// column, refers to the whole row such as 'Module 1'!5:5.
+func cellRef(sheet string, row, col int) (string, string) {
+	quoted := strings.ReplaceAll(sheet, "'", "''")
+	if col < 0 {
+		return "", fmt.Sprintf("'%s'!%d:%d", quoted, row+1, row+1)
+	}
+	column := util.NumberToColumnLetter(int64(col) + 1)
+	return column, fmt.Sprintf("'%s'!%s%d", quoted, column, row+1)
+}
+
+// ErrorIssues converts any error into a list of issues so failures can be
+// reported in the same shape as validation results.
+func ErrorIssues(err error) []Issue {
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return validationErr.Issues
+	}
+	return []Issue{{Severity: SeverityError, Message: err.Error()}}
+}
@@ -0,0 +1,366 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+const (
+	readmeSheet = "README"
+)
+
+const (
+	QuestionTypeSingle   = "single"
+	QuestionTypeMultiple = "multiple"
+	QuestionTypeComplex  = "complex"
+	QuestionTypeShort    = "short"
+	QuestionTypeNumeric  = "numeric"
+)
+
+type Option struct {
+	Content     string   `json:"content"`
+	IsTrue      bool     `json:"isTrue"`
+	OptionOrder int32    `json:"optionOrder"`
+	Explanation string   `json:"explanation"`
+	Points      *float64 `json:"points"`
+}
+
+type Question struct {
+	Content          string   `json:"content"`
+	QuestionOrder    int32    `json:"questionOrder"`
+	Type             string   `json:"type"`
+	Options          []Option `json:"options"`
+	AcceptedAnswers  []string `json:"acceptedAnswers"`
+	CaseSensitive    bool     `json:"caseSensitive"`
+	NumericAnswer    *float64 `json:"numericAnswer"`
+	NumericTolerance *float64 `json:"numericTolerance"`
+	Explanation      string   `json:"explanation"`
+	Points           float64  `json:"points"`
+	WrongPenalty     float64  `json:"wrongPenalty"`
+	BlankScore       float64  `json:"blankScore"`
+	// PassageOrder refers to the passage of the module shown above the
+	// question, nil when the question stands on its own
+	PassageOrder *int32 `json:"passageOrder"`
+	Tags         []Tag  `json:"tags"`
+}
+
+// Passage is a reading text shared by several consecutive questions of a
+// module, so it is stored and rendered once.
+type Passage struct {
+	Content      string `json:"content"`
+	PassageOrder int32  `json:"passageOrder"`
+}
+
+type Module struct {
+	Title       string `json:"title"`
+	ModuleOrder int32  `json:"moduleOrder"`
+	// Duration is the time limit in minutes, zero when the module is untimed
+	Duration     int32      `json:"duration"`
+	Instructions string     `json:"instructions"`
+	Description  string     `json:"description"`
+	Passages     []Passage  `json:"passages"`
+	Questions    []Question `json:"questions"`
+}
+
+type Tryout struct {
+	Metadata Metadata `json:"metadata"`
+	Modules  []Module `json:"modules"`
+}
+
+// Parse turns the raw values of every sheet into a tryout tree. It performs
+// no I/O, so every source of sheet values goes through the same rules. Any
+// error-level issue is returned as a *ValidationError.
+func Parse(sheets []util.SheetData) (*Tryout, error) {
+	tryout, issues := parse(sheets)
+	if HasErrors(issues) {
+		return nil, &ValidationError{Issues: issues}
+	}
+	return tryout, nil
+}
+
+// Validate walks every sheet and reports all errors and warnings instead of
+// stopping at the first one.
+func Validate(sheets []util.SheetData) []Issue {
+	_, issues := parse(sheets)
+	return issues
+}
+
+func parse(sheets []util.SheetData) (*Tryout, []Issue) {
+	tryout := &Tryout{Modules: []Module{}}
+	issues := []Issue{}
+
+	for _, sheet := range sheets {
+		if sheet.Title == readmeSheet {
+			p := sheetParser{sheet: sheet.Title}
+			tryout.Metadata = p.metadata(sheet.Values)
+			issues = append(issues, p.issues...)
+			continue
+		}
+
+		module := Module{
+			Title:       sheet.Title,
+			ModuleOrder: int32(len(tryout.Modules)) + 1,
+		}
+
+		p := sheetParser{sheet: sheet.Title}
+		p.settings(sheet.Values, &module)
+		if p.readHeader(sheet.Values) {
+			module.Questions, module.Passages = p.questions(sheet.Values)
+		}
+		issues = append(issues, p.issues...)
+
+		tryout.Modules = append(tryout.Modules, module)
+	}
+
+	if len(tryout.Modules) == 0 {
+		issues = append(issues, Issue{Severity: SeverityError, Message: "no module sheet to import"})
+	}
+
+	return tryout, issues
+}
+
+type sheetParser struct {
+	sheet string
+	// header is the index of the header row, below the module settings
+	header  int
+	columns columns
+	issues  []Issue
+}
+
+// report records an issue at the cell of the given field in a zero-based
+// row. A negative row reports the issue against the whole sheet.
+func (p *sheetParser) report(severity string, row int, f field, format string, args ...interface{}) {
+	p.reportCell(severity, row, p.columns.index(f), format, args...)
+}
+
+func (p *sheetParser) reportCell(severity string, row, col int, format string, args ...interface{}) {
+	issue := Issue{
+		Severity: severity,
+		Sheet:    p.sheet,
+		Message:  fmt.Sprintf(format, args...),
+	}
+	if row >= 0 {
+		issue.Row = row + 1
+		issue.Column, issue.Cell = cellRef(p.sheet, row, col)
+	}
+	p.issues = append(p.issues, issue)
+}
+
+// questions reads the questions and passages of a single module sheet. Every
+// row below the header is either the start of a question (number, question,
+// answer and first option), an additional option or a passage applied to the
+// questions that follow it.
+func (p *sheetParser) questions(values [][]interface{}) ([]Question, []Passage) {
+	questions := []Question{}
+	passages := []Passage{}
+	var group *passageGroup
+	var reader *rowReader
+	inQuestion := false
+	seen := map[string]int{}
+
+	flush := func() {
+		if reader == nil {
+			return
+		}
+		if q := p.question(reader); q != nil {
+			questions = append(questions, *q)
+		}
+		reader = nil
+	}
+
+	for i := p.header + 1; i < len(values); i++ {
+		row := p.readRow(i, values[i])
+
+		if row.isBlank() {
+			continue
+		}
+
+		if len(row.Passage) > 0 {
+			if len(row.Number) == 0 && len(row.Question) == 0 && len(row.Answer) == 0 && len(row.Option) == 0 {
+				flush()
+				inQuestion = false
+				p.closePassage(group)
+
+				passage := Passage{Content: row.Passage, PassageOrder: int32(len(passages)) + 1}
+				if group = p.passage(row, passage.PassageOrder); group != nil {
+					passages = append(passages, passage)
+				}
+				continue
+			}
+			p.report(SeverityError, i, fieldPassage, "passage must be on its own row above its questions")
+		} else if len(row.PassageQuestions) > 0 {
+			p.report(SeverityWarning, i, fieldPassageQuestions, "%s is only read from passage rows", fieldPassageQuestions)
+		}
+
+		if len(row.Number) == 0 && len(row.Question) == 0 && len(row.Answer) == 0 {
+			if reader == nil {
+				// options of a question row that was already reported are skipped
+				if !inQuestion {
+					p.report(SeverityError, i, fieldOption, "option %q does not belong to any question", row.Option)
+				}
+				continue
+			}
+			if len(row.Option) == 0 {
+				p.report(SeverityError, i, fieldOption, "option is missing")
+				continue
+			}
+			if len(row.Explanation) > 0 {
+				p.report(SeverityWarning, i, fieldExplanation, "explanation is only read from the question row, use %s for options", fieldOptionExplanation)
+			}
+			if len(row.Points) > 0 || len(row.WrongPenalty) > 0 || len(row.BlankScore) > 0 {
+				p.reportCell(SeverityWarning, i, -1, "scores are only read from the question row, use %s for options", fieldOptionPoints)
+			}
+			if row.hasTags() {
+				p.reportCell(SeverityWarning, i, -1, "tags are only read from the question row")
+			}
+			reader.Options = append(reader.Options, row)
+			continue
+		}
+
+		flush()
+		inQuestion = true
+		valid := true
+
+		// invalid questions still count towards the passage above them
+		passageOrder := group.next()
+
+		questionType, err := parseQuestionType(row.Type)
+		if err != nil {
+			p.report(SeverityError, i, fieldType, "%v", err)
+			valid = false
+		}
+
+		if len(row.Number) == 0 {
+			p.report(SeverityError, i, fieldNumber, "number is missing")
+			valid = false
+		}
+		if len(row.Question) == 0 {
+			p.report(SeverityError, i, fieldQuestion, "question is missing")
+			valid = false
+		}
+		// complex questions carry a key per statement instead of an answer
+		if len(row.Answer) == 0 && len(row.Key) == 0 {
+			if questionType == QuestionTypeComplex {
+				p.report(SeverityError, i, fieldKey, "statement key is missing, use benar or salah")
+			} else {
+				p.report(SeverityError, i, fieldAnswer, "answer is missing")
+			}
+			valid = false
+		}
+		// short-answer and numeric questions have no options
+		if len(row.Option) == 0 && !isOpenType(questionType) {
+			p.report(SeverityError, i, fieldOption, "question has no options")
+			valid = false
+		}
+		if !valid {
+			continue
+		}
+
+		if prev, ok := seen[row.Number]; ok {
+			p.report(SeverityWarning, i, fieldNumber, "number %s is already used in row %d", row.Number, prev+1)
+		}
+		seen[row.Number] = i
+
+		reader = &rowReader{
+			sheetRow:     row,
+			QuestionType: questionType,
+			PassageOrder: passageOrder,
+			Options:      []sheetRow{row},
+		}
+	}
+
+	flush()
+	p.closePassage(group)
+
+	if len(questions) == 0 && !HasErrors(p.issues) {
+		p.reportCell(SeverityError, -1, 0, "no data found in sheet")
+	}
+
+	return questions, passages
+}
+
+type sheetRow struct {
+	Row           int
+	Number        string
+	Question      string
+	Answer        string
+	Option        string
+	Key           string
+	Type          string
+	Tolerance     string
+	CaseSensitive string
+	// Explanation is only read from the question row
+	Explanation       string
+	OptionExplanation string
+	// scores are only read from the question row
+	Points       string
+	WrongPenalty string
+	BlankScore   string
+	OptionPoints string
+	// Passage and PassageQuestions are only read from passage rows
+	Passage          string
+	PassageQuestions string
+	// tags are only read from the question row
+	Topic             string
+	Subtopic          string
+	Difficulty        string
+	LearningObjective string
+}
+
+func (p *sheetParser) readRow(i int, row []interface{}) sheetRow {
+	cell := func(f field) string {
+		return cellString(row, p.columns.index(f))
+	}
+
+	return sheetRow{
+		Row:               i,
+		Number:            cell(fieldNumber),
+		Question:          cell(fieldQuestion),
+		Answer:            cell(fieldAnswer),
+		Option:            cell(fieldOption),
+		Key:               cell(fieldKey),
+		Type:              cell(fieldType),
+		Tolerance:         cell(fieldTolerance),
+		CaseSensitive:     cell(fieldCaseSensitive),
+		Explanation:       cell(fieldExplanation),
+		OptionExplanation: cell(fieldOptionExplanation),
+		Points:            cell(fieldPoints),
+		WrongPenalty:      cell(fieldWrongPenalty),
+		BlankScore:        cell(fieldBlankScore),
+		OptionPoints:      cell(fieldOptionPoints),
+		Passage:           cell(fieldPassage),
+		PassageQuestions:  cell(fieldPassageQuestions),
+		Topic:             cell(fieldTopic),
+		Subtopic:          cell(fieldSubtopic),
+		Difficulty:        cell(fieldDifficulty),
+		LearningObjective: cell(fieldLearningObjective),
+	}
+}
+
+func (row sheetRow) isBlank() bool {
+	return len(row.Number) == 0 && len(row.Question) == 0 && len(row.Answer) == 0 && len(row.Option) == 0 &&
+		len(row.Key) == 0 && len(row.Type) == 0 && len(row.Tolerance) == 0 && len(row.CaseSensitive) == 0 &&
+		len(row.Explanation) == 0 && len(row.OptionExplanation) == 0 &&
+		len(row.Points) == 0 && len(row.WrongPenalty) == 0 && len(row.BlankScore) == 0 && len(row.OptionPoints) == 0 &&
+		len(row.Passage) == 0 && len(row.PassageQuestions) == 0 && !row.hasTags()
+}
+
+// rowReader collects the question row together with the rows of its
+// options; the question row holds the first option.
+type rowReader struct {
+	sheetRow
+	// QuestionType is empty when the type column was left blank
+	QuestionType string
+	// PassageOrder is nil when no passage applies to the question
+	PassageOrder *int32
+	Options      []sheetRow
+}
+
+func cellString(row []interface{}, col int) string {
+	if col < 0 || col >= len(row) || row[col] == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(row[col]))
+}
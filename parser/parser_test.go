@@ -0,0 +1,115 @@
+package parser
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+func row(cells ...interface{}) []interface{} {
+	return cells
+}
+
+func moduleSheet(rows ...[]interface{}) []util.SheetData {
+	values := append([][]interface{}{row("No", "Soal", "Kunci", "Pilihan")}, rows...)
+	return []util.SheetData{{Title: "Modul 1", Values: values}}
+}
+
+func TestParseAnswers(t *testing.T) {
+	tests := []struct {
+		name     string
+		sheets   []util.SheetData
+		wantTrue []bool
+		wantCell string
+	}{
+		{
+			name: "lowercase answer",
+			sheets: moduleSheet(
+				row("1", "Soal", "b", "satu"), row("", "", "", "dua"), row("", "", "", "tiga"),
+			),
+			wantTrue: []bool{false, true, false},
+		},
+		{
+			name: "identical options",
+			sheets: moduleSheet(
+				row("1", "Soal", "A", "0"), row("", "", "", "0"), row("", "", "", "None"), row("", "", "", "None"),
+			),
+			wantTrue: []bool{true, false, false, false},
+		},
+		{
+			name: "answer out of range",
+			sheets: moduleSheet(
+				row("1", "Soal", "D", "satu"), row("", "", "", "dua"),
+			),
+			wantCell: "'Modul 1'!C2",
+		},
+		{
+			name: "lowercase answer out of range",
+			sheets: moduleSheet(
+				row("1", "Soal", "e", "satu"), row("", "", "", "dua"),
+			),
+			wantCell: "'Modul 1'!C2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tryout, err := Parse(tt.sheets)
+
+			if len(tt.wantCell) > 0 {
+				var validationErr *ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("Parse() error = %v, want a *ValidationError", err)
+				}
+				if cell := validationErr.Errors()[0].Cell; cell != tt.wantCell {
+					t.Errorf("error reported at %s, want %s", cell, tt.wantCell)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Parse() returned error: %v", err)
+			}
+			options := tryout.Modules[0].Questions[0].Options
+			if len(options) != len(tt.wantTrue) {
+				t.Fatalf("got %d options, want %d", len(options), len(tt.wantTrue))
+			}
+			for i, option := range options {
+				if option.IsTrue != tt.wantTrue[i] {
+					t.Errorf("option %d IsTrue = %v, want %v", i+1, option.IsTrue, tt.wantTrue[i])
+				}
+				if option.OptionOrder != int32(i+1) {
+					t.Errorf("option %d OptionOrder = %d, want %d", i+1, option.OptionOrder, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestParseWithoutModules(t *testing.T) {
+	tests := []struct {
+		name   string
+		sheets []util.SheetData
+	}{
+		{name: "no sheets"},
+		{
+			name:   "readme only",
+			sheets: []util.SheetData{{Title: readmeSheet, Values: [][]interface{}{row("Judul", "Tryout")}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.sheets)
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Parse() error = %v, want a *ValidationError", err)
+			}
+			if msg := validationErr.Error(); msg != "no module sheet to import" {
+				t.Errorf("Parse() error = %q, want %q", msg, "no module sheet to import")
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package parser
+
+import "strconv"
+
+// passageGroup tracks the passage applied to the questions below it.
+type passageGroup struct {
+	order int32
+	row   int
+	total int
+	// remaining counts the questions the passage has yet to be applied to
+	remaining int
+}
+
+// passage reads a passage row, which states in fieldPassageQuestions how many
+// of the following questions share the passage. It returns nil when the
+// number can't be read.
+func (p *sheetParser) passage(row sheetRow, order int32) *passageGroup {
+	if len(row.PassageQuestions) == 0 {
+		p.report(SeverityError, row.Row, fieldPassageQuestions, "number of questions of the passage is missing")
+		return nil
+	}
+
+	total, err := strconv.Atoi(row.PassageQuestions)
+	if err != nil || total <= 0 {
+		p.report(SeverityError, row.Row, fieldPassageQuestions, "number of questions %q is not a positive whole number", row.PassageQuestions)
+		return nil
+	}
+
+	return &passageGroup{order: order, row: row.Row, total: total, remaining: total}
+}
+
+// next returns the passage of the next question, or nil once the passage has
+// been applied to all of its questions.
+func (group *passageGroup) next() *int32 {
+	if group == nil || group.remaining == 0 {
+		return nil
+	}
+	group.remaining--
+
+	order := group.order
+	return &order
+}
+
+// closePassage reports a passage followed by fewer questions than it states,
+// either at the end of the sheet or where the next passage starts.
+func (p *sheetParser) closePassage(group *passageGroup) {
+	if group == nil || group.remaining == 0 {
+		return
+	}
+	p.report(SeverityError, group.row, fieldPassageQuestions, "passage applies to %d questions but only %d follow it",
+		group.total, group.total-group.remaining)
+}
@@ -0,0 +1,325 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+var questionTypeAliases = map[string]string{
+	"single":        QuestionTypeSingle,
+	"pg":            QuestionTypeSingle,
+	"pilihan ganda": QuestionTypeSingle,
+	"multiple":      QuestionTypeMultiple,
+	"pgm":           QuestionTypeMultiple,
+	"complex":       QuestionTypeComplex,
+	"pgk":           QuestionTypeComplex,
+	"kompleks":      QuestionTypeComplex,
+	"short":         QuestionTypeShort,
+	"isian":         QuestionTypeShort,
+	"isian singkat": QuestionTypeShort,
+	"numeric":       QuestionTypeNumeric,
+	"angka":         QuestionTypeNumeric,
+	"isian angka":   QuestionTypeNumeric,
+}
+
+// parseQuestionType returns an empty type for a blank cell, in which case the
+// type is inferred from the answer and keys.
+func parseQuestionType(value string) (string, error) {
+	if len(value) == 0 {
+		return "", nil
+	}
+
+	questionType, ok := questionTypeAliases[strings.ToLower(value)]
+	if !ok {
+		return "", fmt.Errorf("question type %q is not one of single, multiple, complex, short or numeric", value)
+	}
+	return questionType, nil
+}
+
+func isOpenType(questionType string) bool {
+	return questionType == QuestionTypeShort || questionType == QuestionTypeNumeric
+}
+
+func (reader *rowReader) isComplex() bool {
+	for _, option := range reader.Options {
+		if len(option.Key) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (reader *rowReader) questionType() string {
+	switch {
+	case len(reader.QuestionType) > 0:
+		return reader.QuestionType
+	case reader.isComplex():
+		return QuestionTypeComplex
+	}
+	return QuestionTypeSingle
+}
+
+func (p *sheetParser) question(reader *rowReader) *Question {
+	order, err := strconv.Atoi(reader.Number)
+	if err != nil {
+		p.report(SeverityError, reader.Row, fieldNumber, "number %q is not numeric", reader.Number)
+		return nil
+	}
+
+	question := &Question{
+		Content:         reader.Question,
+		QuestionOrder:   int32(order),
+		Type:            reader.questionType(),
+		Options:         []Option{},
+		AcceptedAnswers: []string{},
+		Explanation:     reader.Explanation,
+		PassageOrder:    reader.PassageOrder,
+		Tags:            []Tag{},
+	}
+
+	if !isOpenType(question.Type) {
+		if len(reader.Tolerance) > 0 {
+			p.report(SeverityWarning, reader.Row, fieldTolerance, "tolerance is only used by numeric questions")
+		}
+		if len(reader.CaseSensitive) > 0 {
+			p.report(SeverityWarning, reader.Row, fieldCaseSensitive, "case sensitivity is only used by short-answer questions")
+		}
+	}
+
+	if !p.scores(reader, question) {
+		return nil
+	}
+
+	if !p.tags(reader, question) {
+		return nil
+	}
+
+	var ok bool
+	switch question.Type {
+	case QuestionTypeShort:
+		ok = p.shortAnswer(reader, question)
+	case QuestionTypeNumeric:
+		ok = p.numericAnswer(reader, question)
+	case QuestionTypeComplex:
+		ok = p.complexOptions(reader, question)
+	default:
+		ok = p.choiceOptions(reader, question)
+	}
+	if !ok {
+		return nil
+	}
+
+	return question
+}
+
+func (p *sheetParser) choiceOptions(reader *rowReader, question *Question) bool {
+	for _, option := range reader.Options {
+		if len(option.Key) > 0 {
+			p.report(SeverityError, option.Row, fieldKey, "statement keys are only used by complex questions")
+			return false
+		}
+	}
+
+	answers, err := answerIndexes(reader.Answer, len(reader.Options))
+	if err != nil {
+		p.report(SeverityError, reader.Row, fieldAnswer, "%v", err)
+		return false
+	}
+
+	if len(answers) > 1 {
+		if reader.QuestionType == QuestionTypeSingle {
+			p.report(SeverityError, reader.Row, fieldAnswer, "single-choice question has %d answers", len(answers))
+			return false
+		}
+		question.Type = QuestionTypeMultiple
+	}
+
+	return p.appendOptions(reader, question, answers)
+}
+
+func (p *sheetParser) complexOptions(reader *rowReader, question *Question) bool {
+	keys, err := p.statementKeys(reader)
+	if err != nil {
+		return false
+	}
+
+	return p.appendOptions(reader, question, keys)
+}
+
+func (p *sheetParser) appendOptions(reader *rowReader, question *Question, correct map[int]bool) bool {
+	points, ok := p.optionPoints(reader)
+	if !ok {
+		return false
+	}
+
+	contents := map[string]bool{}
+	for _, option := range reader.Options {
+		if contents[option.Option] {
+			p.report(SeverityWarning, option.Row, fieldOption, "option %q appears more than once in question %s", option.Option, reader.Number)
+		}
+		contents[option.Option] = true
+	}
+
+	for optionOrder, option := range reader.Options {
+		question.Options = append(question.Options, Option{
+			Content:     option.Option,
+			IsTrue:      correct[optionOrder],
+			OptionOrder: int32(optionOrder) + 1,
+			Explanation: option.OptionExplanation,
+			Points:      points[optionOrder],
+		})
+	}
+	return true
+}
+
+// shortAnswer reads the accepted answers of a short-answer question. Several
+// spellings can be accepted by separating them with "|".
+func (p *sheetParser) shortAnswer(reader *rowReader, question *Question) bool {
+	if !p.noOptions(reader) {
+		return false
+	}
+
+	for _, answer := range strings.Split(reader.Answer, "|") {
+		answer = strings.TrimSpace(answer)
+		if len(answer) > 0 {
+			question.AcceptedAnswers = append(question.AcceptedAnswers, answer)
+		}
+	}
+	if len(question.AcceptedAnswers) == 0 {
+		p.report(SeverityError, reader.Row, fieldAnswer, "answer is missing")
+		return false
+	}
+
+	if len(reader.CaseSensitive) > 0 {
+		caseSensitive, err := parseBool(reader.CaseSensitive)
+		if err != nil {
+			p.report(SeverityError, reader.Row, fieldCaseSensitive, "%v", err)
+			return false
+		}
+		question.CaseSensitive = caseSensitive
+	}
+
+	return true
+}
+
+func (p *sheetParser) numericAnswer(reader *rowReader, question *Question) bool {
+	if !p.noOptions(reader) {
+		return false
+	}
+
+	value, err := parseNumber(reader.Answer)
+	if err != nil {
+		p.report(SeverityError, reader.Row, fieldAnswer, "answer %q is not a number", reader.Answer)
+		return false
+	}
+
+	var tolerance float64
+	if len(reader.Tolerance) > 0 {
+		tolerance, err = parseNumber(reader.Tolerance)
+		if err != nil || tolerance < 0 {
+			p.report(SeverityError, reader.Row, fieldTolerance, "tolerance %q is not a positive number", reader.Tolerance)
+			return false
+		}
+	}
+
+	question.NumericAnswer = &value
+	question.NumericTolerance = &tolerance
+	return true
+}
+
+func (p *sheetParser) noOptions(reader *rowReader) bool {
+	for _, option := range reader.Options {
+		if len(option.Option) > 0 || len(option.Key) > 0 || len(option.OptionExplanation) > 0 || len(option.OptionPoints) > 0 {
+			p.report(SeverityError, option.Row, fieldOption, "%s question can't have options", reader.questionType())
+			return false
+		}
+	}
+	return true
+}
+
+// statementKeys reads the benar/salah key of every statement of a complex
+// question, indexed like the options.
+func (p *sheetParser) statementKeys(reader *rowReader) (map[int]bool, error) {
+	var err error
+	if len(reader.Answer) > 0 {
+		err = fmt.Errorf("complex question takes its key per statement, answer must be empty")
+		p.report(SeverityError, reader.Row, fieldAnswer, "%v", err)
+	}
+
+	keys := map[int]bool{}
+	for i, option := range reader.Options {
+		key, keyErr := statementKey(option.Key)
+		if keyErr != nil {
+			err = keyErr
+			p.report(SeverityError, option.Row, fieldKey, "%v", keyErr)
+			continue
+		}
+		keys[i] = key
+	}
+
+	return keys, err
+}
+
+func statementKey(key string) (bool, error) {
+	switch strings.ToLower(key) {
+	case "benar", "b", "true", "t":
+		return true, nil
+	case "salah", "s", "false", "f":
+		return false, nil
+	case "":
+		return false, fmt.Errorf("statement key is missing, use benar or salah")
+	}
+	return false, fmt.Errorf("statement key %q is not benar or salah", key)
+}
+
+// answerIndexes resolves an answer key such as "B", "A,C" or "ACD" to the
+// zero-based indexes of the options it refers to. Letters are
+// case-insensitive and may be separated by commas, semicolons or spaces.
+func answerIndexes(answer string, options int) (map[int]bool, error) {
+	indexes := map[int]bool{}
+
+	for _, r := range strings.ToUpper(answer) {
+		switch {
+		case r == ',' || r == ';' || r == ' ':
+			continue
+		case r < 'A' || r > 'Z':
+			return nil, fmt.Errorf("answer %q is not a list of option letters", answer)
+		}
+
+		index := int(r - 'A')
+		if index >= options {
+			return nil, fmt.Errorf("answer %c has no matching option, question has %d options (A-%s)", r, options, util.NumberToColumnLetter(int64(options)))
+		}
+		if indexes[index] {
+			return nil, fmt.Errorf("answer %q contains %c more than once", answer, r)
+		}
+		indexes[index] = true
+	}
+
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("answer %q is not a list of option letters", answer)
+	}
+
+	return indexes, nil
+}
+
+// parseNumber accepts both "3.5" and the Indonesian "3,5".
+func parseNumber(value string) (float64, error) {
+	if !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "ya", "y", "true", "t", "1":
+		return true, nil
+	case "no", "tidak", "n", "false", "f", "0":
+		return false, nil
+	}
+	return false, fmt.Errorf("%q is not yes or no", value)
+}
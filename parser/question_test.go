@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnswerIndexes(t *testing.T) {
+	tests := []struct {
+		name    string
+		answer  string
+		options int
+		want    map[int]bool
+		wantErr bool
+	}{
+		{name: "single letter", answer: "B", options: 4, want: map[int]bool{1: true}},
+		{name: "lowercase letter", answer: "b", options: 4, want: map[int]bool{1: true}},
+		{name: "list of letters", answer: "A, c;D", options: 4, want: map[int]bool{0: true, 2: true, 3: true}},
+		{name: "last option", answer: "E", options: 5, want: map[int]bool{4: true}},
+		{name: "letter out of range", answer: "E", options: 4, wantErr: true},
+		{name: "lowercase letter out of range", answer: "z", options: 4, wantErr: true},
+		{name: "repeated letter", answer: "A, a", options: 4, wantErr: true},
+		{name: "not a letter", answer: "1", options: 4, wantErr: true},
+		{name: "separators only", answer: ", ;", options: 4, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := answerIndexes(tt.answer, tt.options)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("answerIndexes(%q, %d) = %v, want an error", tt.answer, tt.options, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("answerIndexes(%q, %d) returned error: %v", tt.answer, tt.options, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("answerIndexes(%q, %d) = %v, want %v", tt.answer, tt.options, got, tt.want)
+			}
+		})
+	}
+}
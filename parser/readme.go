@@ -0,0 +1,145 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Metadata is read from the optional key/value block of the README sheet:
+// every row whose first cell is a known key holds its value in the second
+// cell. Any other row of the README is left for the author's instructions.
+type Metadata struct {
+	Title       string `json:"title"`
+	Price       string `json:"price"`
+	Status      string `json:"status"`
+	StartedAt   string `json:"startedAt"`
+	EndedAt     string `json:"endedAt"`
+	Description string `json:"description"`
+	// Duration is the length of the tryout in minutes, zero when not set
+	Duration int32 `json:"duration"`
+}
+
+type metadataKey int
+
+const (
+	keyTitle metadataKey = iota
+	keyPrice
+	keyStatus
+	keyStartedAt
+	keyEndedAt
+	keyDescription
+	keyDuration
+	keyCount
+)
+
+var metadataKeyNames = [keyCount][]string{
+	keyTitle:       {"Judul", "Nama Tryout", "Title"},
+	keyPrice:       {"Harga", "Price"},
+	keyStatus:      {"Status"},
+	keyStartedAt:   {"Mulai", "Waktu Mulai", "Tanggal Mulai", "startedAt", "Started At"},
+	keyEndedAt:     {"Selesai", "Waktu Selesai", "Tanggal Selesai", "endedAt", "Ended At"},
+	keyDescription: {"Deskripsi", "Keterangan", "Description"},
+	keyDuration:    {"Durasi", "Duration"},
+}
+
+var metadataKeys = func() map[string]metadataKey {
+	m := map[string]metadataKey{}
+	for key, names := range metadataKeyNames {
+		for _, name := range names {
+			m[normalizeHeader(name)] = metadataKey(key)
+		}
+	}
+	return m
+}()
+
+// timeLayouts are the accepted date formats. Times without a zone are read
+// as UTC.
+var timeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02/01/2006 15:04:05",
+	"02/01/2006 15:04",
+	"02/01/2006",
+}
+
+func (p *sheetParser) metadata(values [][]interface{}) Metadata {
+	var meta Metadata
+	seen := map[metadataKey]int{}
+
+	for i, row := range values {
+		key, ok := metadataKeys[normalizeHeader(cellString(row, 0))]
+		if !ok {
+			continue
+		}
+		value := cellString(row, 1)
+		if len(value) == 0 {
+			continue
+		}
+
+		if prev, ok := seen[key]; ok {
+			p.reportCell(SeverityWarning, i, 0, "%s is already set in row %d", metadataKeyNames[key][0], prev+1)
+		}
+		seen[key] = i
+
+		switch key {
+		case keyTitle:
+			meta.Title = value
+		case keyPrice:
+			price, err := parseNumber(value)
+			if err != nil || price < 0 {
+				p.reportCell(SeverityError, i, 1, "price %q is not a positive number", value)
+				continue
+			}
+			meta.Price = strconv.FormatFloat(price, 'f', -1, 64)
+		case keyStatus:
+			meta.Status = value
+		case keyStartedAt, keyEndedAt:
+			t, err := parseTime(value)
+			if err != nil {
+				p.reportCell(SeverityError, i, 1, "%s %q is not a date, use a format such as 2006-01-02 15:04", metadataKeyNames[key][0], value)
+				continue
+			}
+			if key == keyStartedAt {
+				meta.StartedAt = t.Format(time.RFC3339)
+			} else {
+				meta.EndedAt = t.Format(time.RFC3339)
+			}
+		case keyDescription:
+			meta.Description = value
+		case keyDuration:
+			duration, err := parseMinutes(value)
+			if err != nil {
+				p.reportCell(SeverityError, i, 1, "duration %q is not a positive number of minutes", value)
+				continue
+			}
+			meta.Duration = duration
+		}
+	}
+
+	return meta
+}
+
+func parseMinutes(value string) (int32, error) {
+	minutes, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	if minutes <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	return int32(minutes), nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	var err error
+	for _, layout := range timeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
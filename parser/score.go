@@ -0,0 +1,66 @@
+package parser
+
+import "math"
+
+const (
+	defaultPoints       = 1
+	defaultWrongPenalty = 0
+	defaultBlankScore   = 0
+)
+
+// scores reads the optional scoring columns of a question. The penalty is
+// the number of points deducted for a wrong answer, so authors may write it
+// either as 1 or -1.
+func (p *sheetParser) scores(reader *rowReader, question *Question) bool {
+	question.Points = defaultPoints
+	question.WrongPenalty = defaultWrongPenalty
+	question.BlankScore = defaultBlankScore
+
+	if len(reader.Points) > 0 {
+		points, err := parseNumber(reader.Points)
+		if err != nil || points < 0 {
+			p.report(SeverityError, reader.Row, fieldPoints, "points %q is not a positive number", reader.Points)
+			return false
+		}
+		question.Points = points
+	}
+
+	if len(reader.WrongPenalty) > 0 {
+		penalty, err := parseNumber(reader.WrongPenalty)
+		if err != nil {
+			p.report(SeverityError, reader.Row, fieldWrongPenalty, "penalty %q is not a number", reader.WrongPenalty)
+			return false
+		}
+		question.WrongPenalty = math.Abs(penalty)
+	}
+
+	if len(reader.BlankScore) > 0 {
+		blank, err := parseNumber(reader.BlankScore)
+		if err != nil {
+			p.report(SeverityError, reader.Row, fieldBlankScore, "blank score %q is not a number", reader.BlankScore)
+			return false
+		}
+		question.BlankScore = blank
+	}
+
+	return true
+}
+
+// optionPoints reads the points of every option, which override the points
+// of the question when the option is chosen.
+func (p *sheetParser) optionPoints(reader *rowReader) ([]*float64, bool) {
+	points := make([]*float64, len(reader.Options))
+	for i, option := range reader.Options {
+		if len(option.OptionPoints) == 0 {
+			continue
+		}
+
+		value, err := parseNumber(option.OptionPoints)
+		if err != nil {
+			p.report(SeverityError, option.Row, fieldOptionPoints, "option points %q is not a number", option.OptionPoints)
+			return nil, false
+		}
+		points[i] = &value
+	}
+	return points, true
+}
@@ -0,0 +1,68 @@
+package parser
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+// Selection decides which sheets of a spreadsheet are imported as modules.
+type Selection struct {
+	// IgnorePrefix skips sheets whose title starts with it, such as scratch
+	// sheets named "_draft". An empty prefix skips nothing.
+	IgnorePrefix string
+	// Sheets lists the titles or gids to import. When it is empty every
+	// visible sheet without the ignore prefix is imported.
+	Sheets []string
+}
+
+// SelectSheets filters the sheets of a spreadsheet. Hidden and prefixed
+// sheets are skipped unless they are listed explicitly, and the README sheet
+// is always kept for its metadata. A listed sheet that doesn't exist is
+// returned as a *ValidationError.
+func SelectSheets(sheets []util.SheetData, selection Selection) ([]util.SheetData, error) {
+	listed := map[string]bool{}
+	for _, name := range selection.Sheets {
+		listed[strings.TrimSpace(name)] = false
+	}
+
+	selected := []util.SheetData{}
+	for _, sheet := range sheets {
+		matched := false
+		for _, name := range []string{sheet.Title, strconv.FormatInt(sheet.SheetId, 10)} {
+			if _, ok := listed[name]; ok {
+				listed[name] = true
+				matched = true
+			}
+		}
+
+		switch {
+		case sheet.Title == readmeSheet:
+		case matched:
+		case len(listed) > 0:
+			continue
+		case sheet.Hidden:
+			continue
+		case len(selection.IgnorePrefix) > 0 && strings.HasPrefix(sheet.Title, selection.IgnorePrefix):
+			continue
+		}
+		selected = append(selected, sheet)
+	}
+
+	issues := []Issue{}
+	for _, name := range selection.Sheets {
+		if !listed[strings.TrimSpace(name)] {
+			issues = append(issues, Issue{
+				Severity: SeverityError,
+				Sheet:    name,
+				Message:  "sheet is not found in the spreadsheet",
+			})
+		}
+	}
+	if len(issues) > 0 {
+		return nil, &ValidationError{Issues: issues}
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,54 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+func TestSelectSheets(t *testing.T) {
+	sheets := []util.SheetData{
+		{Title: "README", SheetId: 0},
+		{Title: "Modul 1", SheetId: 1},
+		{Title: "_draft", SheetId: 2},
+		{Title: "Modul 2", SheetId: 3, Hidden: true},
+		{Title: "Modul 3", SheetId: 4},
+	}
+
+	tests := []struct {
+		name    string
+		sheets  []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "all visible sheets", want: []string{"README", "Modul 1", "Modul 3"}},
+		{name: "listed by title", sheets: []string{"Modul 2"}, want: []string{"README", "Modul 2"}},
+		{name: "listed by gid", sheets: []string{"2"}, want: []string{"README", "_draft"}},
+		{name: "readme listed", sheets: []string{"README", "Modul 1"}, want: []string{"README", "Modul 1"}},
+		{name: "missing sheet", sheets: []string{"Modul 9"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := SelectSheets(sheets, Selection{IgnorePrefix: "_", Sheets: tt.sheets})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SelectSheets() selected %d sheets, want an error", len(selected))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SelectSheets() returned error: %v", err)
+			}
+
+			titles := []string{}
+			for _, sheet := range selected {
+				titles = append(titles, sheet.Title)
+			}
+			if !reflect.DeepEqual(titles, tt.want) {
+				t.Errorf("SelectSheets() = %v, want %v", titles, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package parser
+
+type settingKey int
+
+const (
+	settingTitle settingKey = iota
+	settingDuration
+	settingInstructions
+	settingDescription
+	settingCount
+)
+
+var settingKeyNames = [settingCount][]string{
+	settingTitle:        {"Judul", "Nama Modul", "Title"},
+	settingDuration:     {"Durasi", "Waktu", "Duration"},
+	settingInstructions: {"Petunjuk", "Instruksi", "Instructions"},
+	settingDescription:  {"Deskripsi", "Keterangan", "Description"},
+}
+
+var settingKeys = func() map[string]settingKey {
+	m := map[string]settingKey{}
+	for key, names := range settingKeyNames {
+		for _, name := range names {
+			m[normalizeHeader(name)] = settingKey(key)
+		}
+	}
+	return m
+}()
+
+// settings reads the reserved rows above the header of a module sheet. Each
+// of them holds a setting name in the first cell and its value in the second
+// one; the first other non-blank row is the header. A title replaces the
+// sheet name, which spreadsheet programs limit in length and characters.
+func (p *sheetParser) settings(values [][]interface{}, module *Module) {
+	seen := map[settingKey]int{}
+
+	for p.header = 0; p.header < len(values); p.header++ {
+		row := values[p.header]
+		if isBlankRow(row) {
+			continue
+		}
+
+		key, ok := settingKeys[normalizeHeader(cellString(row, 0))]
+		if !ok {
+			return
+		}
+
+		i := p.header
+		value := cellString(row, 1)
+		if len(value) == 0 {
+			continue
+		}
+
+		if prev, ok := seen[key]; ok {
+			p.reportCell(SeverityWarning, i, 0, "%s is already set in row %d", settingKeyNames[key][0], prev+1)
+		}
+		seen[key] = i
+
+		switch key {
+		case settingTitle:
+			module.Title = value
+		case settingDuration:
+			duration, err := parseMinutes(value)
+			if err != nil {
+				p.reportCell(SeverityError, i, 1, "duration %q is not a positive number of minutes", value)
+				continue
+			}
+			module.Duration = duration
+		case settingInstructions:
+			module.Instructions = value
+		case settingDescription:
+			module.Description = value
+		}
+	}
+}
+
+func isBlankRow(row []interface{}) bool {
+	for col := range row {
+		if len(cellString(row, col)) > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package parser
+
+import "strings"
+
+const (
+	TagTypeTopic             = "topic"
+	TagTypeSubtopic          = "subtopic"
+	TagTypeDifficulty        = "difficulty"
+	TagTypeLearningObjective = "learningObjective"
+)
+
+const (
+	DifficultyEasy   = "mudah"
+	DifficultyMedium = "sedang"
+	DifficultyHard   = "sulit"
+)
+
+var difficultyAliases = map[string]string{
+	"mudah":  DifficultyEasy,
+	"easy":   DifficultyEasy,
+	"sedang": DifficultyMedium,
+	"medium": DifficultyMedium,
+	"sulit":  DifficultyHard,
+	"sukar":  DifficultyHard,
+	"hard":   DifficultyHard,
+}
+
+// Tag classifies a question for analysing results, such as its topic or its
+// difficulty level.
+type Tag struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// tagTypeFields maps every tag type to the column it is read from.
+var tagTypeFields = map[string]field{
+	TagTypeTopic:             fieldTopic,
+	TagTypeSubtopic:          fieldSubtopic,
+	TagTypeDifficulty:        fieldDifficulty,
+	TagTypeLearningObjective: fieldLearningObjective,
+}
+
+// tags reads the optional tag columns of a question row. Difficulty levels
+// are normalized so results can be grouped by them.
+func (p *sheetParser) tags(reader *rowReader, question *Question) bool {
+	tags := []Tag{
+		{Type: TagTypeTopic, Name: reader.Topic},
+		{Type: TagTypeSubtopic, Name: reader.Subtopic},
+		{Type: TagTypeDifficulty, Name: reader.Difficulty},
+		{Type: TagTypeLearningObjective, Name: reader.LearningObjective},
+	}
+
+	for _, tag := range tags {
+		if len(tag.Name) == 0 {
+			continue
+		}
+
+		if tag.Type == TagTypeDifficulty {
+			difficulty, ok := difficultyAliases[strings.ToLower(tag.Name)]
+			if !ok {
+				p.report(SeverityError, reader.Row, fieldDifficulty, "difficulty %q is not one of %s, %s or %s",
+					tag.Name, DifficultyEasy, DifficultyMedium, DifficultyHard)
+				return false
+			}
+			tag.Name = difficulty
+		}
+
+		question.Tags = append(question.Tags, tag)
+	}
+	return true
+}
+
+func (row sheetRow) hasTags() bool {
+	return len(row.Topic) > 0 || len(row.Subtopic) > 0 || len(row.Difficulty) > 0 || len(row.LearningObjective) > 0
+}
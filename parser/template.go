@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/online-tryout/parsing-sheets-api/util"
+)
+
+const templateModule = "Modul 1"
+
+// fieldDescriptions documents every column in the README of the template.
+var fieldDescriptions = [fieldCount]string{
+	fieldNumber:            "question number, also its order in the module",
+	fieldQuestion:          "question text, written on the first row of the question",
+	fieldAnswer:            "letters of the correct options such as A or A, C; the accepted answers separated by | for short answers; the number for numeric questions",
+	fieldOption:            "one option per row, the first one on the question row",
+	fieldKey:               "benar or salah for every statement of a complex question",
+	fieldType:              "single, multiple, complex, short or numeric; inferred from the answer when left blank",
+	fieldTolerance:         "accepted difference from the answer of a numeric question",
+	fieldCaseSensitive:     "ya when a short answer must match upper and lower case",
+	fieldExplanation:       "explanation of the question shown after the tryout",
+	fieldOptionExplanation: "explanation of the option on the same row",
+	fieldPoints:            "points for a correct answer, 1 by default",
+	fieldWrongPenalty:      "points deducted for a wrong answer, 0 by default",
+	fieldBlankScore:        "points for a question left blank, 0 by default",
+	fieldOptionPoints:      "points for choosing the option on the same row, overriding the question points",
+	fieldPassage:           "reading text on its own row, shown once above the questions below it",
+	fieldPassageQuestions:  "number of questions below the passage row that share the passage",
+	fieldTopic:             "topic of the question, used to analyse results",
+	fieldSubtopic:          "subtopic of the question within its topic",
+	fieldDifficulty:        "mudah, sedang or sulit",
+	fieldLearningObjective: "learning objective or competency the question assesses",
+}
+
+// Template builds an empty workbook to fill in: a README documenting the
+// rules with the tryout details left blank, an empty module sheet and, when
+// examplePrefix is set, an example module that is skipped on import.
+func Template(examplePrefix string) []util.SheetData {
+	readme := util.SheetData{
+		Title:  readmeSheet,
+		Values: append(templateRules(examplePrefix), []interface{}{}),
+	}
+	for _, names := range metadataKeyNames {
+		readme.Values = append(readme.Values, []interface{}{names[0], ""})
+	}
+
+	sheets := []util.SheetData{
+		readme,
+		formatModule(Module{Title: templateModule, Questions: []Question{}}, templateModule, true),
+	}
+	if len(examplePrefix) > 0 {
+		example := exampleModule(examplePrefix + "Contoh")
+		sheets = append(sheets, formatModule(example, example.Title, true))
+	}
+
+	for i := range sheets {
+		sheets[i].SheetId = int64(i)
+		sheets[i].Index = int64(i)
+	}
+	return sheets
+}
+
+// templateRules are written in the first column of the README. None of the
+// lines is a metadata key, so they are ignored on import.
+func templateRules(examplePrefix string) [][]interface{} {
+	lines := []string{
+		"How to fill in this spreadsheet",
+		"Every sheet other than README becomes a module, in the order of the sheets. Hidden sheets are skipped.",
+		"Fill in the tryout details below; values sent with the import request take precedence over them.",
+		fmt.Sprintf("Dates use the format 2006-01-02 15:04 and durations are in minutes. Module sheets may set %s, %s, %s and %s in the rows above the header.",
+			settingKeyNames[settingTitle][0], settingKeyNames[settingDuration][0], settingKeyNames[settingInstructions][0], settingKeyNames[settingDescription][0]),
+		fmt.Sprintf("A module is named after its sheet unless %s is set, for titles longer than %d characters or with characters a sheet name can't hold.",
+			settingKeyNames[settingTitle][0], maxSheetTitle),
+		fmt.Sprintf("The header row is matched by name, so columns may be reordered and extra columns are ignored. %s are required.",
+			strings.Join(requiredFieldNames(), ", ")),
+		fmt.Sprintf("A reading passage shared by several questions goes in %s on a row of its own, with %s set to the number of questions that follow it.",
+			fieldPassage, fieldPassageQuestions),
+	}
+	if len(examplePrefix) > 0 {
+		lines = append(lines, fmt.Sprintf("Sheets whose name starts with %s, like the example sheet, are not imported.", examplePrefix))
+	}
+
+	lines = append(lines, "")
+	for f := field(0); f < fieldCount; f++ {
+		lines = append(lines, fmt.Sprintf("Column %s: %s.", f, fieldDescriptions[f]))
+	}
+
+	values := make([][]interface{}, len(lines))
+	for i, line := range lines {
+		values[i] = []interface{}{line}
+	}
+	return values
+}
+
+func requiredFieldNames() []string {
+	names := make([]string, len(requiredFields))
+	for i, f := range requiredFields {
+		names[i] = f.String()
+	}
+	return names
+}
+
+func exampleModule(title string) Module {
+	points := 2.0
+	answer, tolerance := 3.14, 0.01
+	passage := int32(1)
+
+	return Module{
+		Title:        title,
+		Duration:     30,
+		Instructions: "Kerjakan semua soal dengan teliti.",
+		Passages: []Passage{{
+			Content:      "Candi Borobudur dibangun pada abad ke-9 oleh Dinasti Syailendra dan merupakan candi Buddha terbesar di dunia.",
+			PassageOrder: passage,
+		}},
+		Questions: []Question{
+			{
+				Content: "Ibu kota Indonesia adalah ...", QuestionOrder: 1, Type: QuestionTypeSingle,
+				Explanation: "Jakarta adalah ibu kota Indonesia.", Points: points,
+				Tags:    []Tag{{Type: TagTypeTopic, Name: "Geografi"}, {Type: TagTypeDifficulty, Name: DifficultyEasy}},
+				Options: []Option{{Content: "Jakarta", IsTrue: true}, {Content: "Bandung"}, {Content: "Surabaya"}, {Content: "Medan"}},
+			},
+			{
+				Content: "Manakah yang termasuk bilangan prima?", QuestionOrder: 2, Type: QuestionTypeMultiple,
+				Points: points, WrongPenalty: 1,
+				Tags: []Tag{
+					{Type: TagTypeTopic, Name: "Bilangan"}, {Type: TagTypeSubtopic, Name: "Bilangan Prima"},
+					{Type: TagTypeDifficulty, Name: DifficultyMedium}, {Type: TagTypeLearningObjective, Name: "Mengidentifikasi bilangan prima"},
+				},
+				Options: []Option{{Content: "2", IsTrue: true}, {Content: "4"}, {Content: "5", IsTrue: true}, {Content: "9"}},
+			},
+			{
+				Content: "Tentukan benar atau salah pernyataan berikut.", QuestionOrder: 3, Type: QuestionTypeComplex,
+				Points:  points,
+				Options: []Option{{Content: "Air mendidih pada 100 °C", IsTrue: true}, {Content: "Matahari mengelilingi bumi"}},
+			},
+			{
+				Content: "Sebutkan ibu kota Jawa Barat.", QuestionOrder: 4, Type: QuestionTypeShort,
+				Points: points, AcceptedAnswers: []string{"Bandung", "Kota Bandung"},
+			},
+			{
+				Content: "Berapakah nilai pi hingga dua angka desimal?", QuestionOrder: 5, Type: QuestionTypeNumeric,
+				Points: points, NumericAnswer: &answer, NumericTolerance: &tolerance,
+			},
+			{
+				Content: "Candi Borobudur dibangun oleh dinasti ...", QuestionOrder: 6, Type: QuestionTypeSingle,
+				Points: points, PassageOrder: &passage,
+				Options: []Option{{Content: "Sanjaya"}, {Content: "Syailendra", IsTrue: true}, {Content: "Majapahit"}},
+			},
+			{
+				Content: "Borobudur merupakan candi agama ...", QuestionOrder: 7, Type: QuestionTypeShort,
+				Points: points, PassageOrder: &passage, AcceptedAnswers: []string{"Buddha"},
+			},
+		},
+	}
+}
@@ -1,14 +1,26 @@
 package util
 
 import (
+	"time"
+
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	ServerAddress      string `mapstructure:"BACKEND_SERVER_ADDRESS"`
-	RabbitSource       string `mapstructure:"RABBIT_SOURCE"`
-	ServerUrl          string `mapstructure:"SERVER_URL"`
-	BackendSwaggerHost string `mapstructure:"BACKEND_SWAGGER_HOST"`
+	DBDriver            string        `mapstructure:"DB_DRIVER"`
+	DBSource            string        `mapstructure:"DB_SOURCE"`
+	DBMaxOpenConns      int           `mapstructure:"DB_MAX_OPEN_CONNS"`
+	DBMaxIdleConns      int           `mapstructure:"DB_MAX_IDLE_CONNS"`
+	DBConnMaxLifetime   time.Duration `mapstructure:"DB_CONN_MAX_LIFETIME"`
+	ServerAddress       string        `mapstructure:"BACKEND_SERVER_ADDRESS"`
+	RabbitSource        string        `mapstructure:"RABBIT_SOURCE"`
+	RabbitReplyQueue    string        `mapstructure:"RABBIT_REPLY_QUEUE"`
+	RabbitMaxAttempts   int           `mapstructure:"RABBIT_MAX_ATTEMPTS"`
+	RabbitRetryDelay    time.Duration `mapstructure:"RABBIT_RETRY_DELAY"`
+	RabbitMaxRetryDelay time.Duration `mapstructure:"RABBIT_MAX_RETRY_DELAY"`
+	ServerUrl           string        `mapstructure:"SERVER_URL"`
+	BackendSwaggerHost  string        `mapstructure:"BACKEND_SWAGGER_HOST"`
+	SheetIgnorePrefix   string        `mapstructure:"SHEET_IGNORE_PREFIX"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -16,6 +28,16 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigName("app")
 	viper.SetConfigType("env")
 
+	viper.SetDefault("DB_DRIVER", "postgres")
+	viper.SetDefault("DB_MAX_OPEN_CONNS", 10)
+	viper.SetDefault("DB_MAX_IDLE_CONNS", 5)
+	viper.SetDefault("DB_CONN_MAX_LIFETIME", 30*time.Minute)
+	viper.SetDefault("RABBIT_REPLY_QUEUE", "parsing-sheets-result-queue")
+	viper.SetDefault("RABBIT_MAX_ATTEMPTS", 5)
+	viper.SetDefault("RABBIT_RETRY_DELAY", 5*time.Second)
+	viper.SetDefault("RABBIT_MAX_RETRY_DELAY", 5*time.Minute)
+	viper.SetDefault("SHEET_IGNORE_PREFIX", "_")
+
 	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
@@ -0,0 +1,142 @@
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ReadCSVZip reads a zip archive of CSV files, one per sheet. Each sheet is
+// named after its file without the extension, so README.csv holds the
+// metadata and every other file becomes a module in archive order.
+func ReadCSVZip(r io.ReaderAt, size int64) ([]SheetData, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read zip archive: %v", err)
+	}
+
+	// the limits are shared by every file of the archive
+	remainingSize := int64(maxDecompressedSize)
+	remainingCells := maxWorkbookCells
+
+	var result []SheetData
+	for _, file := range archive.File {
+		name := path.Base(file.Name)
+		// skip folders and the resource forks added by macOS
+		if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") || strings.HasPrefix(name, ".") {
+			continue
+		}
+		if !strings.EqualFold(path.Ext(name), ".csv") {
+			continue
+		}
+
+		rc, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %v", file.Name, err)
+		}
+		limited := limitReader(rc, remainingSize)
+		values, err := readCSV(limited, remainingCells)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %v", file.Name, err)
+		}
+		remainingSize = limited.remaining
+		for _, row := range values {
+			remainingCells -= len(row)
+		}
+
+		result = append(result, SheetData{
+			Title:   strings.TrimSuffix(name, path.Ext(name)),
+			SheetId: int64(len(result)),
+			Index:   int64(len(result)),
+			Values:  values,
+		})
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("zip archive has no CSV files")
+	}
+
+	return result, nil
+}
+
+// ReadCSV reads the rows of a single CSV file. The delimiter is detected
+// from the first records since spreadsheet programs in many locales export
+// with semicolons.
+func ReadCSV(r io.Reader) ([][]interface{}, error) {
+	return readCSV(r, maxWorkbookCells)
+}
+
+func readCSV(r io.Reader, maxCells int) ([][]interface{}, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = csvDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	values := [][]interface{}{}
+	cells := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		cells += len(record)
+		if cells > maxCells {
+			return nil, errTooManyCells
+		}
+
+		row := make([]interface{}, len(record))
+		for j, cell := range record {
+			row[j] = cell
+		}
+		values = append(values, row)
+	}
+	return values, nil
+}
+
+// csvSniffRecords is how many records csvDelimiter looks at.
+const csvSniffRecords = 10
+
+// csvDelimiter picks the delimiter that most often splits the first records
+// into the same number of fields. Spreadsheet programs export every row as
+// wide as the sheet, while commas or semicolons in the text vary from row to
+// row. Ties go to the wider records, then to a comma.
+func csvDelimiter(data []byte) rune {
+	delimiter, bestRows, bestWidth := ',', 0, 0
+	for _, candidate := range []rune{',', ';', '\t'} {
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.Comma = candidate
+		reader.FieldsPerRecord = -1
+		reader.LazyQuotes = true
+
+		widths := map[int]int{}
+		for i := 0; i < csvSniffRecords; i++ {
+			record, err := reader.Read()
+			if err != nil {
+				break
+			}
+			widths[len(record)]++
+		}
+
+		for width, rows := range widths {
+			if width > 1 && (rows > bestRows || rows == bestRows && width > bestWidth) {
+				delimiter, bestRows, bestWidth = candidate, rows, width
+			}
+		}
+	}
+	return delimiter
+}
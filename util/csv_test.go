@@ -0,0 +1,40 @@
+package util
+
+import "testing"
+
+func TestCSVDelimiter(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want rune
+	}{
+		{
+			name: "comma",
+			data: "No,Soal,Kunci\n1,Soal,A\n",
+			want: ',',
+		},
+		{
+			name: "semicolon with commas in the first line",
+			data: "No;Soal, jika ada, dan lain-lain;Kunci\n1;Soal;A\n2;Soal;B\n",
+			want: ';',
+		},
+		{
+			name: "tab",
+			data: "No\tSoal\tKunci\n1\tA, B; C\tA\n",
+			want: '\t',
+		},
+		{
+			name: "single column",
+			data: "Soal\nSoal\n",
+			want: ',',
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := csvDelimiter([]byte(tt.data)); got != tt.want {
+				t.Errorf("csvDelimiter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
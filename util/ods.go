@@ -0,0 +1,244 @@
+package util
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	odsTableNS  = "urn:oasis:names:tc:opendocument:xmlns:table:1.0"
+	odsTextNS   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+	odsStyleNS  = "urn:oasis:names:tc:opendocument:xmlns:style:1.0"
+	odsOfficeNS = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+)
+
+// ReadODS reads every sheet of an OpenDocument spreadsheet into the same
+// structure FetchSheets returns. Cells hold the text they display, except
+// dates which are written in xlsxDateLayout as ReadXLSX does, and the empty
+// rows and cells an .ods file repeats up to the sheet size are dropped.
+func ReadODS(r io.ReaderAt, size int64) ([]SheetData, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read workbook: %v", err)
+	}
+
+	content, err := archive.Open("content.xml")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read workbook: %v", err)
+	}
+	defer content.Close()
+
+	sheets, err := readODSContent(limitReader(content, maxDecompressedSize))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read workbook: %v", err)
+	}
+	return sheets, nil
+}
+
+type odsReader struct {
+	hiddenStyles map[string]bool
+	style        string
+
+	sheets []SheetData
+	sheet  *SheetData
+	// cells counts the values added to every sheet, repeated ones included
+	cells int
+	err   error
+	// empty rows and cells are only added once content follows them
+	pendingRows  int
+	row          []interface{}
+	rowRepeat    int
+	pendingCells int
+	cellRepeat   int
+	// date is the office:date-value of a date cell
+	date string
+
+	inCell     bool
+	paragraphs int
+	annotation int
+	text       strings.Builder
+}
+
+func readODSContent(r io.Reader) ([]SheetData, error) {
+	decoder := xml.NewDecoder(r)
+	reader := odsReader{hiddenStyles: map[string]bool{}}
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			reader.start(t)
+		case xml.EndElement:
+			reader.end(t)
+			if reader.err != nil {
+				return nil, reader.err
+			}
+		case xml.CharData:
+			if reader.inCell && reader.paragraphs > 0 && reader.annotation == 0 {
+				reader.text.Write(t)
+			}
+		}
+	}
+
+	return reader.sheets, nil
+}
+
+func (r *odsReader) start(t xml.StartElement) {
+	switch t.Name {
+	case xml.Name{Space: odsStyleNS, Local: "style"}:
+		r.style = odsAttr(t, odsStyleNS, "name")
+	case xml.Name{Space: odsStyleNS, Local: "table-properties"}:
+		if odsAttr(t, odsTableNS, "display") == "false" {
+			r.hiddenStyles[r.style] = true
+		}
+	case xml.Name{Space: odsTableNS, Local: "table"}:
+		r.sheet = &SheetData{
+			Title:   odsAttr(t, odsTableNS, "name"),
+			SheetId: int64(len(r.sheets)),
+			Index:   int64(len(r.sheets)),
+			Hidden:  r.hiddenStyles[odsAttr(t, odsTableNS, "style-name")],
+			Values:  [][]interface{}{},
+		}
+		r.pendingRows = 0
+	case xml.Name{Space: odsTableNS, Local: "table-row"}:
+		r.row = []interface{}{}
+		r.rowRepeat = odsRepeat(t, "number-rows-repeated")
+		r.pendingCells = 0
+	case xml.Name{Space: odsTableNS, Local: "table-cell"}, xml.Name{Space: odsTableNS, Local: "covered-table-cell"}:
+		r.inCell = true
+		r.cellRepeat = odsRepeat(t, "number-columns-repeated")
+		r.date = ""
+		if odsAttr(t, odsOfficeNS, "value-type") == "date" {
+			r.date = odsAttr(t, odsOfficeNS, "date-value")
+		}
+		r.paragraphs = 0
+		r.text.Reset()
+	case xml.Name{Space: odsOfficeNS, Local: "annotation"}:
+		r.annotation++
+	case xml.Name{Space: odsTextNS, Local: "p"}:
+		if r.inCell && r.annotation == 0 {
+			if r.paragraphs > 0 {
+				r.text.WriteString("\n")
+			}
+			r.paragraphs++
+		}
+	case xml.Name{Space: odsTextNS, Local: "s"}:
+		if r.inCell && r.annotation == 0 {
+			count, err := strconv.Atoi(odsAttr(t, odsTextNS, "c"))
+			if err != nil || count < 1 {
+				count = 1
+			}
+			r.text.WriteString(strings.Repeat(" ", count))
+		}
+	case xml.Name{Space: odsTextNS, Local: "tab"}:
+		if r.inCell && r.annotation == 0 {
+			r.text.WriteString("\t")
+		}
+	case xml.Name{Space: odsTextNS, Local: "line-break"}:
+		if r.inCell && r.annotation == 0 {
+			r.text.WriteString("\n")
+		}
+	}
+}
+
+func (r *odsReader) end(t xml.EndElement) {
+	switch t.Name {
+	case xml.Name{Space: odsOfficeNS, Local: "annotation"}:
+		r.annotation--
+	case xml.Name{Space: odsTableNS, Local: "table-cell"}, xml.Name{Space: odsTableNS, Local: "covered-table-cell"}:
+		r.inCell = false
+		value := r.text.String()
+		if date, ok := odsDate(r.date); ok {
+			value = date
+		}
+		if len(value) == 0 {
+			r.pendingCells += r.cellRepeat
+			return
+		}
+		if !r.addCells(1, r.pendingCells+r.cellRepeat) {
+			return
+		}
+		for ; r.pendingCells > 0; r.pendingCells-- {
+			r.row = append(r.row, "")
+		}
+		for i := 0; i < r.cellRepeat; i++ {
+			r.row = append(r.row, value)
+		}
+	case xml.Name{Space: odsTableNS, Local: "table-row"}:
+		if r.sheet == nil {
+			return
+		}
+		if len(r.row) == 0 {
+			r.pendingRows += r.rowRepeat
+			return
+		}
+		// the cells of the row were counted once already; empty rows count as
+		// one cell each
+		if !r.addCells(r.pendingRows, 1) || !r.addCells(r.rowRepeat-1, len(r.row)) {
+			return
+		}
+		for ; r.pendingRows > 0; r.pendingRows-- {
+			r.sheet.Values = append(r.sheet.Values, []interface{}{})
+		}
+		for i := 0; i < r.rowRepeat; i++ {
+			r.sheet.Values = append(r.sheet.Values, append([]interface{}{}, r.row...))
+		}
+	case xml.Name{Space: odsTableNS, Local: "table"}:
+		if r.sheet != nil {
+			r.sheets = append(r.sheets, *r.sheet)
+			r.sheet = nil
+		}
+	}
+}
+
+// addCells counts rows of cells about to be added, and fails once the
+// workbook would hold more than maxWorkbookCells.
+func (r *odsReader) addCells(rows, cells int) bool {
+	if rows > maxWorkbookCells || cells > maxWorkbookCells || r.cells+rows*cells > maxWorkbookCells {
+		r.err = errTooManyCells
+		return false
+	}
+	r.cells += rows * cells
+	return true
+}
+
+// odsDateLayouts are the forms of office:date-value, with and without a time.
+var odsDateLayouts = []string{"2006-01-02T15:04:05.999999999", "2006-01-02"}
+
+func odsDate(value string) (string, bool) {
+	for _, layout := range odsDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date.Format(xlsxDateLayout), true
+		}
+	}
+	return "", false
+}
+
+func odsAttr(t xml.StartElement, space, local string) string {
+	for _, attr := range t.Attr {
+		if attr.Name.Space == space && attr.Name.Local == local {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+func odsRepeat(t xml.StartElement, local string) int {
+	repeat, err := strconv.Atoi(odsAttr(t, odsTableNS, local))
+	if err != nil || repeat < 1 {
+		return 1
+	}
+	return repeat
+}
@@ -0,0 +1,26 @@
+package util
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestReadODSDates(t *testing.T) {
+	data := zipFile(t, "content.xml", odsContent(
+		`<table:table-row>`+
+			`<table:table-cell office:value-type="date" office:date-value="2026-01-02T08:30:00"><text:p>02/01/26 08:30</text:p></table:table-cell>`+
+			`<table:table-cell office:value-type="date" office:date-value="2026-02-03"><text:p>3 Feb 2026</text:p></table:table-cell>`+
+			`<table:table-cell office:value-type="float" office:value="3.5"><text:p>3,5</text:p></table:table-cell>`+
+			`</table:table-row>`))
+
+	sheets, err := ReadODS(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("ReadODS() returned error: %v", err)
+	}
+
+	want := [][]interface{}{{"2026-01-02 08:30:00", "2026-02-03 00:00:00", "3,5"}}
+	if !reflect.DeepEqual(sheets[0].Values, want) {
+		t.Errorf("ReadODS() = %v, want %v", sheets[0].Values, want)
+	}
+}
@@ -5,21 +5,34 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 
+	"github.com/xuri/excelize/v2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/drive/v3"
 	"google.golang.org/api/option"
 	"google.golang.org/api/sheets/v4"
 )
 
-type SheetsRowReader struct {
-	Number string `json:"number"`
-	Question string `json:"question"`
-	Answer string `json:"answer"`
-	Option []string `json:"option"`
+type SheetData struct {
+	Title   string
+	SheetId int64
+	Index   int64
+	Hidden  bool
+	Values  [][]interface{}
+	// Validations and ProtectedRows are only applied when a sheet is written
+	Validations   []Validation
+	ProtectedRows []int
 }
 
-func (sheet *SheetsRowReader) IsEmpty() bool {
-	return len(sheet.Number) == 0 && len(sheet.Question) == 0 && len(sheet.Answer) == 0 && len(sheet.Question) == 0
+// Validation offers a dropdown list on a column, from a zero-based row to the
+// end of the sheet. A strict validation rejects other values, otherwise the
+// list is only a suggestion.
+type Validation struct {
+	Column  int
+	FromRow int
+	Values  []string
+	Strict  bool
 }
 
 func NumberToColumnLetter(n int64) string {
@@ -65,13 +78,120 @@ func FetchData(srv *sheets.Service, spreadsheetID, sheetName, readRange string)
         return nil, fmt.Errorf("unable to retrieve data from sheet: %v", err)
     }
 
-	if len(resp.Values) == 0 {
-        return nil, fmt.Errorf("no data found in sheet")
-    }
-
     return resp.Values, nil
 }
 
+// FetchSheets reads every sheet of a spreadsheet, header row included.
+func FetchSheets(srv *sheets.Service, spreadsheetID string) ([]SheetData, error) {
+	spreadsheetInfo, err := GetSpreadsheetInfo(srv, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []SheetData
+	for _, sheet := range spreadsheetInfo.Sheets {
+		props := sheet.Properties
+		row := props.GridProperties.RowCount
+		col := props.GridProperties.ColumnCount
+
+		data, err := FetchData(srv, spreadsheetID, props.Title, fmt.Sprintf("A1:%s%d", NumberToColumnLetter(col), row))
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch data from sheet %s: %v", props.Title, err)
+		}
+
+		result = append(result, SheetData{
+			Title:   props.Title,
+			SheetId: props.SheetId,
+			Index:   props.Index,
+			Hidden:  props.Hidden,
+			Values:  data,
+		})
+	}
+
+	if err := fetchDates(srv, spreadsheetID, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// dateFields selects the serial number and number format type of every cell.
+const dateFields = "sheets(properties/title,data(startRow,startColumn,rowData/values(effectiveValue/numberValue,effectiveFormat/numberFormat/type)))"
+
+// fetchDates replaces the displayed text of cells formatted as a date with the
+// date in xlsxDateLayout, as ReadXLSX does, since the display format depends
+// on the locale of the spreadsheet.
+func fetchDates(srv *sheets.Service, spreadsheetID string, data []SheetData) error {
+	spreadsheet, err := srv.Spreadsheets.Get(spreadsheetID).IncludeGridData(true).Fields(dateFields).Do()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve spreadsheet: %v", err)
+	}
+
+	values := map[string][][]interface{}{}
+	for _, sheet := range data {
+		values[sheet.Title] = sheet.Values
+	}
+
+	for _, sheet := range spreadsheet.Sheets {
+		rows := values[sheet.Properties.Title]
+		for _, grid := range sheet.Data {
+			for i, rowData := range grid.RowData {
+				row := int(grid.StartRow) + i
+				if row >= len(rows) {
+					break
+				}
+
+				for j, cell := range rowData.Values {
+					col := int(grid.StartColumn) + j
+					if col >= len(rows[row]) || !isDateCell(cell) {
+						continue
+					}
+
+					date, err := excelize.ExcelDateToTime(*cell.EffectiveValue.NumberValue, false)
+					if err != nil {
+						continue
+					}
+					rows[row][col] = date.Format(xlsxDateLayout)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// isDateCell reports whether cell holds a number formatted as a date; time-only
+// formats are left as displayed.
+func isDateCell(cell *sheets.CellData) bool {
+	if cell.EffectiveValue == nil || cell.EffectiveValue.NumberValue == nil {
+		return false
+	}
+	if cell.EffectiveFormat == nil || cell.EffectiveFormat.NumberFormat == nil {
+		return false
+	}
+
+	switch cell.EffectiveFormat.NumberFormat.Type {
+	case "DATE", "DATE_TIME":
+		return true
+	}
+	return false
+}
+
+// LoadSpreadsheet fetches all sheets of the Google Sheets document behind url.
+func LoadSpreadsheet(credentialsFile, url string) ([]SheetData, error) {
+	client, err := GetSheetsClient(credentialsFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get Google Sheets client: %v", err)
+	}
+
+	spreadsheetID, err := GetSheetID(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return FetchSheets(client, spreadsheetID)
+}
+
 func GetSheetID(url string) (string, error) {
 	re := regexp.MustCompile(`\/spreadsheets\/d\/([a-zA-Z0-9-_]+)\/edit`)
 	matches := re.FindStringSubmatch(url)
@@ -79,4 +199,128 @@ func GetSheetID(url string) (string, error) {
 		return "", fmt.Errorf("URL is not a valid Google Sheets URL")
 	}
 	return matches[1], nil
-}
\ No newline at end of file
+}
+
+// CreateSpreadsheet creates a Google Sheets document holding the sheets and,
+// when email is not empty, shares it with that account as a writer since the
+// service account owns the document. Values are written as raw text so they
+// are read back exactly as they were written.
+func CreateSpreadsheet(credentialsFile, title string, data []SheetData, email string) (*sheets.Spreadsheet, error) {
+	ctx := context.Background()
+
+	creds, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := google.JWTConfigFromJSON(creds, sheets.SpreadsheetsScope, drive.DriveFileScope)
+	if err != nil {
+		return nil, err
+	}
+	client := config.Client(ctx)
+
+	srv, err := sheets.NewService(ctx, option.WithHTTPClient(client))
+	if err != nil {
+		return nil, err
+	}
+
+	spreadsheet := &sheets.Spreadsheet{
+		Properties: &sheets.SpreadsheetProperties{Title: title},
+	}
+	for i, sheet := range data {
+		spreadsheet.Sheets = append(spreadsheet.Sheets, &sheets.Sheet{
+			Properties: &sheets.SheetProperties{
+				Title:  sheet.Title,
+				Index:  int64(i),
+				Hidden: sheet.Hidden,
+			},
+		})
+	}
+
+	spreadsheet, err = srv.Spreadsheets.Create(spreadsheet).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create spreadsheet: %v", err)
+	}
+
+	values := &sheets.BatchUpdateValuesRequest{ValueInputOption: "RAW"}
+	for _, sheet := range data {
+		if len(sheet.Values) == 0 {
+			continue
+		}
+		values.Data = append(values.Data, &sheets.ValueRange{
+			Range:  fmt.Sprintf("'%s'!A1", strings.ReplaceAll(sheet.Title, "'", "''")),
+			Values: sheet.Values,
+		})
+	}
+
+	if len(values.Data) > 0 {
+		_, err = srv.Spreadsheets.Values.BatchUpdate(spreadsheet.SpreadsheetId, values).Do()
+		if err != nil {
+			return nil, fmt.Errorf("unable to write spreadsheet: %v", err)
+		}
+	}
+
+	requests := []*sheets.Request{}
+	for i, sheet := range data {
+		sheetID := spreadsheet.Sheets[i].Properties.SheetId
+
+		for _, validation := range sheet.Validations {
+			condition := &sheets.BooleanCondition{Type: "ONE_OF_LIST"}
+			for _, value := range validation.Values {
+				condition.Values = append(condition.Values, &sheets.ConditionValue{UserEnteredValue: value})
+			}
+
+			requests = append(requests, &sheets.Request{
+				SetDataValidation: &sheets.SetDataValidationRequest{
+					Range: &sheets.GridRange{
+						SheetId:          sheetID,
+						StartRowIndex:    int64(validation.FromRow),
+						StartColumnIndex: int64(validation.Column),
+						EndColumnIndex:   int64(validation.Column) + 1,
+					},
+					Rule: &sheets.DataValidationRule{
+						Condition:    condition,
+						Strict:       validation.Strict,
+						ShowCustomUi: true,
+					},
+				},
+			})
+		}
+
+		for _, row := range sheet.ProtectedRows {
+			requests = append(requests, &sheets.Request{
+				AddProtectedRange: &sheets.AddProtectedRangeRequest{
+					ProtectedRange: &sheets.ProtectedRange{
+						Range: &sheets.GridRange{
+							SheetId:       sheetID,
+							StartRowIndex: int64(row),
+							EndRowIndex:   int64(row) + 1,
+						},
+					},
+				},
+			})
+		}
+	}
+
+	if len(requests) > 0 {
+		_, err = srv.Spreadsheets.BatchUpdate(spreadsheet.SpreadsheetId, &sheets.BatchUpdateSpreadsheetRequest{Requests: requests}).Do()
+		if err != nil {
+			return nil, fmt.Errorf("unable to protect spreadsheet: %v", err)
+		}
+	}
+
+	if len(email) > 0 {
+		driveSrv, err := drive.NewService(ctx, option.WithHTTPClient(client))
+		if err != nil {
+			return nil, err
+		}
+
+		permission := &drive.Permission{Type: "user", Role: "writer", EmailAddress: email}
+		_, err = driveSrv.Permissions.Create(spreadsheet.SpreadsheetId, permission).Do()
+		if err != nil {
+			return nil, fmt.Errorf("unable to share spreadsheet with %s: %v", email, err)
+		}
+	}
+
+	return spreadsheet, nil
+}
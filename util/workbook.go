@@ -0,0 +1,84 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// maxDecompressedSize caps what the files inside an .xlsx, .ods or zip
+	// upload may expand to, so a small archive can't exhaust memory.
+	maxDecompressedSize = 50 << 20
+	// maxWorkbookCells caps the cells read from an upload, including the
+	// rows and cells an .ods file repeats.
+	maxWorkbookCells = 1000000
+)
+
+var (
+	errDecompressedTooLarge = fmt.Errorf("workbook is larger than %d MB once decompressed", maxDecompressedSize>>20)
+	errTooManyCells         = fmt.Errorf("workbook has more than %d cells", maxWorkbookCells)
+)
+
+// WorkbookExtensions lists the file types ReadWorkbook accepts.
+var WorkbookExtensions = []string{".xlsx", ".ods", ".zip", ".csv"}
+
+// IsWorkbook reports whether ReadWorkbook accepts the file by its extension.
+func IsWorkbook(fileName string) bool {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	for _, supported := range WorkbookExtensions {
+		if ext == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadWorkbook reads an uploaded file by its extension: an .xlsx or .ods
+// workbook, a zip of CSV files or a single CSV file holding one module.
+func ReadWorkbook(fileName string, data []byte) ([]SheetData, error) {
+	reader := bytes.NewReader(data)
+
+	switch ext := strings.ToLower(filepath.Ext(fileName)); ext {
+	case ".xlsx":
+		return ReadXLSX(reader)
+	case ".ods":
+		return ReadODS(reader, reader.Size())
+	case ".zip":
+		return ReadCSVZip(reader, reader.Size())
+	case ".csv":
+		values, err := ReadCSV(reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %v", fileName, err)
+		}
+		return []SheetData{{
+			Title:  strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName)),
+			Values: values,
+		}}, nil
+	default:
+		return nil, fmt.Errorf("file %s is not one of %s", fileName, strings.Join(WorkbookExtensions, ", "))
+	}
+}
+
+// limitedReader fails once more than its limit is read, where io.LimitReader
+// alone would silently cut the data short.
+type limitedReader struct {
+	r io.Reader
+	// remaining is negative once the limit is exceeded
+	remaining int64
+}
+
+func limitReader(r io.Reader, limit int64) *limitedReader {
+	return &limitedReader{r: io.LimitReader(r, limit+1), remaining: limit}
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errDecompressedTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,143 @@
+package util
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func zipFile(t *testing.T, name, content string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	w, err := archive.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// xlsxFile builds a workbook holding the given sheet data, written by hand
+// since excelize fills in every row up to the last one.
+func xlsxFile(t *testing.T, sheetData string) []byte {
+	t.Helper()
+
+	files := []struct{ name, content string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"` +
+			` xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Modul 1" sheetId="1" r:id="rId1"/></sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`},
+		{"xl/worksheets/sheet1.xml", `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<sheetData>` + sheetData + `</sheetData></worksheet>`},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, file := range files {
+		w, err := archive.Create(file.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(file.content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func odsContent(rows string) string {
+	return `<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0"` +
+		` xmlns:table="urn:oasis:names:tc:opendocument:xmlns:table:1.0"` +
+		` xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">` +
+		`<office:body><office:spreadsheet><table:table table:name="Modul 1">` + rows +
+		`</table:table></office:spreadsheet></office:body></office:document-content>`
+}
+
+func TestReadWorkbookLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		data     []byte
+		wantErr  error
+	}{
+		{
+			name:     "xlsx",
+			fileName: "tryout.xlsx",
+			data:     xlsxFile(t, `<row r="1"><c r="A1" t="inlineStr"><is><t>A</t></is></c></row>`),
+		},
+		{
+			name:     "too many xlsx rows",
+			fileName: "tryout.xlsx",
+			data:     xlsxFile(t, `<row r="1000001"><c r="A1000001" t="inlineStr"><is><t>A</t></is></c></row>`),
+			wantErr:  errTooManyCells,
+		},
+		{
+			name:     "ods",
+			fileName: "tryout.ods",
+			data: zipFile(t, "content.xml", odsContent(
+				`<table:table-row><table:table-cell table:number-columns-repeated="2"><text:p>A</text:p></table:table-cell></table:table-row>`+
+					`<table:table-row table:number-rows-repeated="1048576"><table:table-cell table:number-columns-repeated="1024"/></table:table-row>`)),
+		},
+		{
+			name:     "repeated ods cells",
+			fileName: "tryout.ods",
+			data: zipFile(t, "content.xml", odsContent(
+				`<table:table-row table:number-rows-repeated="1048576"><table:table-cell table:number-columns-repeated="1024"><text:p>A</text:p></table:table-cell></table:table-row>`)),
+			wantErr: errTooManyCells,
+		},
+		{
+			name:     "csv zip",
+			fileName: "tryout.zip",
+			data:     zipFile(t, "Modul 1.csv", "No,Soal\n1,Soal\n"),
+		},
+		{
+			name:     "decompressed csv too large",
+			fileName: "tryout.zip",
+			data:     zipFile(t, "Modul 1.csv", strings.Repeat("a", maxDecompressedSize+1)),
+			wantErr:  errDecompressedTooLarge,
+		},
+		{
+			name:     "too many csv cells",
+			fileName: "tryout.csv",
+			data:     []byte(strings.Repeat(",", maxWorkbookCells)),
+			wantErr:  errTooManyCells,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadWorkbook(tt.fileName, tt.data)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("ReadWorkbook() returned error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr.Error()) {
+				t.Fatalf("ReadWorkbook() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package util
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxDateLayout is accepted by the parser as a date, whatever the display
+// format of the cell.
+const xlsxDateLayout = "2006-01-02 15:04:05"
+
+// ReadXLSX reads every worksheet of an .xlsx workbook into the same structure
+// FetchSheets returns, with cells formatted as they are displayed, so uploads
+// go through the same parser as Google Sheets. Dates are the exception: they
+// are written in xlsxDateLayout since their display format depends on the
+// locale of whoever typed them.
+func ReadXLSX(r io.Reader) ([]SheetData, error) {
+	f, err := excelize.OpenReader(r, excelize.Options{
+		UnzipSizeLimit:    maxDecompressedSize,
+		UnzipXMLSizeLimit: maxDecompressedSize,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read workbook: %v", err)
+	}
+	defer f.Close()
+
+	props, err := f.GetWorkbookProps()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read workbook: %v", err)
+	}
+	date1904 := props.Date1904 != nil && *props.Date1904
+
+	sheetIDs := map[string]int64{}
+	for id, name := range f.GetSheetMap() {
+		sheetIDs[name] = int64(id)
+	}
+
+	var result []SheetData
+	remaining := maxWorkbookCells
+	for index, name := range f.GetSheetList() {
+		// the raw values are the same cells read again
+		rawRemaining := remaining
+		rows, err := readRows(f, name, &remaining)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read sheet %s: %v", name, err)
+		}
+
+		rawRows, err := readRows(f, name, &rawRemaining, excelize.Options{RawCellValue: true})
+		if err != nil {
+			return nil, fmt.Errorf("unable to read sheet %s: %v", name, err)
+		}
+
+		visible, err := f.GetSheetVisible(name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read sheet %s: %v", name, err)
+		}
+
+		values := make([][]interface{}, len(rows))
+		for i, row := range rows {
+			values[i] = make([]interface{}, len(row))
+			for j, cell := range row {
+				values[i][j] = cell
+				if i >= len(rawRows) || j >= len(rawRows[i]) || rawRows[i][j] == cell {
+					continue
+				}
+
+				date, err := readDate(f, name, i, j, rawRows[i][j], date1904)
+				if err != nil {
+					return nil, fmt.Errorf("unable to read sheet %s: %v", name, err)
+				}
+				if len(date) > 0 {
+					values[i][j] = date
+				}
+			}
+		}
+
+		result = append(result, SheetData{
+			Title:   name,
+			SheetId: sheetIDs[name],
+			Index:   int64(index),
+			Hidden:  !visible,
+			Values:  values,
+		})
+	}
+
+	return result, nil
+}
+
+// readRows reads the rows of a sheet as GetRows does, but fails once more
+// than remaining cells are read instead of holding every row in memory first.
+// Empty rows count as one cell each.
+func readRows(f *excelize.File, sheet string, remaining *int, opts ...excelize.Options) ([][]string, error) {
+	rows, err := f.Rows(sheet)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result [][]string
+	last := 0
+	for rows.Next() {
+		row, err := rows.Columns(opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		*remaining -= max(len(row), 1)
+		if *remaining < 0 {
+			return nil, errTooManyCells
+		}
+
+		result = append(result, row)
+		// trailing empty rows are dropped like GetRows does
+		if len(row) > 0 {
+			last = len(result)
+		}
+	}
+	if err := rows.Error(); err != nil {
+		return nil, err
+	}
+
+	return result[:last], nil
+}
+
+// readDate returns the date held by a cell formatted as a date, or an empty
+// string for any other cell.
+func readDate(f *excelize.File, sheet string, row, col int, raw string, date1904 bool) (string, error) {
+	serial, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return "", nil
+	}
+
+	cell, err := excelize.CoordinatesToCellName(col+1, row+1)
+	if err != nil {
+		return "", err
+	}
+	styleID, err := f.GetCellStyle(sheet, cell)
+	if err != nil {
+		return "", err
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		return "", err
+	}
+	if !isDateFormat(style) {
+		return "", nil
+	}
+
+	date, err := excelize.ExcelDateToTime(serial, date1904)
+	if err != nil {
+		return "", nil
+	}
+	return date.Format(xlsxDateLayout), nil
+}
+
+// nonDateFormatParts are the parts of a number format that are displayed as
+// they are: quoted text, escaped characters, and colors or locales in brackets.
+var nonDateFormatParts = regexp.MustCompile(`"[^"]*"|\\.|\[[^\]]*\]`)
+
+func isDateFormat(style *excelize.Style) bool {
+	if style.CustomNumFmt != nil {
+		code := nonDateFormatParts.ReplaceAllString(strings.ToLower(*style.CustomNumFmt), "")
+		// time-only formats are left as displayed
+		return strings.ContainsAny(code, "yd")
+	}
+
+	// built-in date formats, including the locale specific ones
+	id := style.NumFmt
+	return (id >= 14 && id <= 17) || id == 22 || (id >= 27 && id <= 36) || (id >= 50 && id <= 58)
+}
+
+// WriteXLSX writes the sheets as worksheets of a new .xlsx workbook.
+func WriteXLSX(w io.Writer, sheets []SheetData) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	defaultSheet := f.GetSheetName(0)
+	for i, sheet := range sheets {
+		if i == 0 {
+			if err := f.SetSheetName(defaultSheet, sheet.Title); err != nil {
+				return fmt.Errorf("unable to create sheet %s: %v", sheet.Title, err)
+			}
+		} else if _, err := f.NewSheet(sheet.Title); err != nil {
+			return fmt.Errorf("unable to create sheet %s: %v", sheet.Title, err)
+		}
+
+		for j, row := range sheet.Values {
+			cell, err := excelize.CoordinatesToCellName(1, j+1)
+			if err != nil {
+				return err
+			}
+			if err := f.SetSheetRow(sheet.Title, cell, &row); err != nil {
+				return fmt.Errorf("unable to write sheet %s: %v", sheet.Title, err)
+			}
+		}
+
+		if err := writeValidations(f, sheet); err != nil {
+			return fmt.Errorf("unable to write sheet %s: %v", sheet.Title, err)
+		}
+
+		if err := protectRows(f, sheet); err != nil {
+			return fmt.Errorf("unable to protect sheet %s: %v", sheet.Title, err)
+		}
+
+		if sheet.Hidden {
+			if err := f.SetSheetVisible(sheet.Title, false); err != nil {
+				return err
+			}
+		}
+	}
+
+	return f.Write(w)
+}
+
+func writeValidations(f *excelize.File, sheet SheetData) error {
+	for _, validation := range sheet.Validations {
+		from, err := excelize.CoordinatesToCellName(validation.Column+1, validation.FromRow+1)
+		if err != nil {
+			return err
+		}
+		to, err := excelize.CoordinatesToCellName(validation.Column+1, excelize.TotalRows)
+		if err != nil {
+			return err
+		}
+
+		dv := excelize.NewDataValidation(true)
+		dv.Sqref = from + ":" + to
+		if err := dv.SetDropList(validation.Values); err != nil {
+			return err
+		}
+		if validation.Strict {
+			dv.SetError(excelize.DataValidationErrorStyleStop, "Invalid value", "Choose one of "+strings.Join(validation.Values, ", "))
+		}
+
+		if err := f.AddDataValidation(sheet.Title, dv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protectRows locks the protected rows of a sheet. Excel protects a whole
+// sheet, so the columns authors fill in are unlocked first.
+func protectRows(f *excelize.File, sheet SheetData) error {
+	if len(sheet.ProtectedRows) == 0 {
+		return nil
+	}
+
+	unlocked, err := f.NewStyle(&excelize.Style{Protection: &excelize.Protection{Locked: false}})
+	if err != nil {
+		return err
+	}
+	locked, err := f.NewStyle(&excelize.Style{Protection: &excelize.Protection{Locked: true}, Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	if err := f.SetColStyle(sheet.Title, "A:Z", unlocked); err != nil {
+		return err
+	}
+	for _, row := range sheet.ProtectedRows {
+		if err := f.SetRowStyle(sheet.Title, row+1, row+1, locked); err != nil {
+			return err
+		}
+	}
+
+	return f.ProtectSheet(sheet.Title, &excelize.SheetProtectionOptions{
+		AutoFilter:          true,
+		DeleteRows:          true,
+		FormatCells:         true,
+		FormatColumns:       true,
+		FormatRows:          true,
+		InsertRows:          true,
+		SelectLockedCells:   true,
+		SelectUnlockedCells: true,
+		Sort:                true,
+	})
+}
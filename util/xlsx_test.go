@@ -0,0 +1,55 @@
+package util
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func TestReadXLSXDates(t *testing.T) {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	dateTime := "dd/mm/yyyy hh:mm"
+	suffix := `0.0"d"`
+	cells := []struct {
+		value interface{}
+		style excelize.Style
+	}{
+		{time.Date(2026, 1, 2, 8, 30, 0, 0, time.UTC), excelize.Style{NumFmt: 14}},
+		{time.Date(2026, 2, 3, 9, 0, 0, 0, time.UTC), excelize.Style{CustomNumFmt: &dateTime}},
+		{3.5, excelize.Style{CustomNumFmt: &suffix}},
+		{50000, excelize.Style{}},
+	}
+	for i, c := range cells {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		style, err := f.NewStyle(&c.style)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.SetCellValue("Sheet1", cell, c.value); err != nil {
+			t.Fatal(err)
+		}
+		if err := f.SetCellStyle("Sheet1", cell, cell, style); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := f.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	sheets, err := ReadXLSX(&buf)
+	if err != nil {
+		t.Fatalf("ReadXLSX() returned error: %v", err)
+	}
+
+	want := [][]interface{}{{"2026-01-02 08:30:00"}, {"2026-02-03 09:00:00"}, {"3.5d"}, {"50000"}}
+	if !reflect.DeepEqual(sheets[0].Values, want) {
+		t.Errorf("ReadXLSX() = %v, want %v", sheets[0].Values, want)
+	}
+}